@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"sort"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+// bound is one end of an interval. A nil *bound means the interval is not
+// bounded on that side.
+type bound struct {
+	version   blangsemver.Version
+	inclusive bool
+}
+
+// interval is a contiguous set of versions; all comparator sets of a range
+// can be reduced to one interval each.
+type interval struct {
+	lower *bound
+	upper *bound
+}
+
+// successor returns the smallest version that is larger than v. Since
+// prerelease identifiers can be appended indefinitely, this is either v with
+// an additional ".0" prerelease identifier or, for stable versions, the
+// lowest prerelease of the next patch version.
+func successor(v blangsemver.Version) blangsemver.Version {
+	next := copyVersion(v)
+	next.Build = nil
+
+	if len(next.Pre) > 0 {
+		next.Pre = append(next.Pre, blangsemver.PRVersion{VersionNum: 0, IsNum: true})
+		return next
+	}
+
+	return upperBound(next.Major, next.Minor, next.Patch+1)
+}
+
+// lowerKey returns the smallest version matched by a lower bound, which allows
+// to compare exclusive and inclusive bounds with each other.
+func lowerKey(b *bound) blangsemver.Version {
+	if b == nil {
+		return upperBound(0, 0, 0)
+	}
+
+	if b.inclusive {
+		return b.version
+	}
+
+	return successor(b.version)
+}
+
+// compareUpper compares two upper bounds by the smallest version that is not
+// matched by them anymore. Unbounded is larger than everything else.
+func compareUpper(a, b *bound) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	default:
+		return upperKey(a).Compare(upperKey(b))
+	}
+}
+
+func upperKey(b *bound) blangsemver.Version {
+	if b.inclusive {
+		return successor(b.version)
+	}
+
+	return b.version
+}
+
+func (i interval) empty() bool {
+	return i.upper != nil && lowerKey(i.lower).Compare(upperKey(i.upper)) >= 0
+}
+
+func (i interval) intersect(other interval) interval {
+	result := i
+
+	if lowerKey(other.lower).Compare(lowerKey(result.lower)) > 0 {
+		result.lower = other.lower
+	}
+
+	if compareUpper(other.upper, result.upper) < 0 {
+		result.upper = other.upper
+	}
+
+	return result
+}
+
+func (i interval) comparators() []Comparator {
+	if i.empty() {
+		return []Comparator{nothing()}
+	}
+
+	if i.lower != nil && i.upper != nil && i.lower.inclusive && i.upper.inclusive && i.lower.version.Compare(i.upper.version) == 0 {
+		return []Comparator{{Operator: OpEqual, Version: i.lower.version}}
+	}
+
+	result := []Comparator{}
+
+	if i.lower != nil && lowerKey(i.lower).Compare(upperBound(0, 0, 0)) > 0 {
+		op := OpGreater
+		if i.lower.inclusive {
+			op = OpGreaterEqual
+		}
+
+		result = append(result, Comparator{Operator: op, Version: i.lower.version})
+	}
+
+	if i.upper != nil {
+		op := OpLess
+		if i.upper.inclusive {
+			op = OpLessEqual
+		}
+
+		result = append(result, Comparator{Operator: op, Version: i.upper.version})
+	}
+
+	return result
+}
+
+func comparatorInterval(c Comparator) interval {
+	switch c.Operator {
+	case OpEqual:
+		return interval{
+			lower: &bound{version: c.Version, inclusive: true},
+			upper: &bound{version: c.Version, inclusive: true},
+		}
+	case OpGreater:
+		return interval{lower: &bound{version: c.Version}}
+	case OpGreaterEqual:
+		return interval{lower: &bound{version: c.Version, inclusive: true}}
+	case OpLess:
+		return interval{upper: &bound{version: c.Version}}
+	case OpLessEqual:
+		return interval{upper: &bound{version: c.Version, inclusive: true}}
+	default:
+		return interval{upper: &bound{version: upperBound(0, 0, 0)}}
+	}
+}
+
+// intervals returns the range as a sorted list of disjoint, non-empty
+// intervals.
+func (r Range) intervals() []interval {
+	result := []interval{}
+
+	for _, set := range r.Sets {
+		i := interval{}
+		for _, c := range set {
+			i = i.intersect(comparatorInterval(c))
+		}
+
+		if !i.empty() {
+			result = append(result, i)
+		}
+	}
+
+	return mergeIntervals(result)
+}
+
+func mergeIntervals(intervals []interval) []interval {
+	sort.SliceStable(intervals, func(i, j int) bool {
+		return lowerKey(intervals[i].lower).Compare(lowerKey(intervals[j].lower)) < 0
+	})
+
+	result := []interval{}
+
+	for _, i := range intervals {
+		if len(result) == 0 {
+			result = append(result, i)
+			continue
+		}
+
+		last := &result[len(result)-1]
+
+		// intervals touch or overlap
+		if last.upper == nil || lowerKey(i.lower).Compare(upperKey(last.upper)) <= 0 {
+			if compareUpper(i.upper, last.upper) > 0 {
+				last.upper = i.upper
+			}
+
+			continue
+		}
+
+		result = append(result, i)
+	}
+
+	return result
+}
+
+func rangeFromIntervals(intervals []interval) Range {
+	sets := make([][]Comparator, len(intervals))
+	for i, in := range intervals {
+		sets[i] = in.comparators()
+	}
+
+	return Range{Sets: sets}
+}
+
+// IsEmpty returns true if no version can satisfy the range.
+func (r Range) IsEmpty() bool {
+	return len(r.intervals()) == 0
+}
+
+// Intersect returns a range that is satisfied by all versions that satisfy
+// both ranges. The result is normalized into disjoint, sorted alternatives.
+func (r Range) Intersect(other Range) Range {
+	result := []interval{}
+
+	for _, a := range r.intervals() {
+		for _, b := range other.intervals() {
+			if i := a.intersect(b); !i.empty() {
+				result = append(result, i)
+			}
+		}
+	}
+
+	return rangeFromIntervals(mergeIntervals(result))
+}
+
+// Union returns a range that is satisfied by all versions that satisfy at
+// least one of the ranges. The result is normalized into disjoint, sorted
+// alternatives.
+func (r Range) Union(other Range) Range {
+	return rangeFromIntervals(mergeIntervals(append(r.intervals(), other.intervals()...)))
+}
+
+// Complement returns a range that is satisfied by exactly those versions that
+// do not satisfy r.
+func (r Range) Complement() Range {
+	result := []interval{}
+	current := interval{}
+
+	for _, i := range r.intervals() {
+		if i.lower != nil {
+			current.upper = &bound{version: i.lower.version, inclusive: !i.lower.inclusive}
+			if !current.empty() {
+				result = append(result, current)
+			}
+		}
+
+		if i.upper == nil {
+			return rangeFromIntervals(result)
+		}
+
+		current = interval{
+			lower: &bound{version: i.upper.version, inclusive: !i.upper.inclusive},
+		}
+	}
+
+	return rangeFromIntervals(append(result, current))
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"testing"
+)
+
+func mustParseRange(t *testing.T, constraint string) Range {
+	t.Helper()
+
+	r, err := ParseRange(constraint)
+	if err != nil {
+		t.Fatalf("Failed to parse range %q: %v", constraint, err)
+	}
+
+	return r
+}
+
+func TestRangeIsEmpty(t *testing.T) {
+	testcases := []struct {
+		constraint string
+		expected   bool
+	}{
+		{constraint: "*", expected: false},
+		{constraint: "<0.0.0-0", expected: true},
+		{constraint: ">=1.2 <2", expected: false},
+		{constraint: ">=2 <1.2", expected: true},
+		{constraint: ">=1.2.3 <=1.2.3", expected: false},
+		{constraint: ">1.2.3 <=1.2.3", expected: true},
+		{constraint: ">=1.2.3 <1.2.3", expected: true},
+		{constraint: ">1.2.3 <1.2.4-0", expected: true},
+		{constraint: ">1.2.3 <1.2.4-0.0", expected: false},
+		{constraint: ">=2 <1 || 1.2.3", expected: false},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.constraint, func(t *testing.T) {
+			if empty := mustParseRange(t, testcase.constraint).IsEmpty(); empty != testcase.expected {
+				t.Fatalf("Expected %v, but got %v", testcase.expected, empty)
+			}
+		})
+	}
+}
+
+func TestRangeIntersect(t *testing.T) {
+	testcases := []struct {
+		a, b     string
+		expected string
+	}{
+		{a: ">=1.2 <2", b: "^1.5 || ^3", expected: ">=1.5.0 <2.0.0-0"},
+		{a: ">=1.2 <2", b: "^3", expected: "<0.0.0-0"},
+		{a: "*", b: "~1.2.3", expected: ">=1.2.3 <1.3.0-0"},
+		{a: "<=1.2.3", b: ">=1.2.3", expected: "=1.2.3"},
+		{a: "^1 || ^3", b: ">=1.5 <3.2", expected: ">=1.5.0 <2.0.0-0 || >=3.0.0 <3.2.0-0"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.a+" & "+testcase.b, func(t *testing.T) {
+			result := mustParseRange(t, testcase.a).Intersect(mustParseRange(t, testcase.b))
+			if s := result.String(); s != testcase.expected {
+				t.Fatalf("Expected %q, but got %q", testcase.expected, s)
+			}
+		})
+	}
+}
+
+func TestRangeUnion(t *testing.T) {
+	testcases := []struct {
+		a, b     string
+		expected string
+	}{
+		{a: "^1.2", b: "^1.5", expected: ">=1.2.0 <2.0.0-0"},
+		{a: "^1", b: "^2", expected: ">=1.0.0 <2.0.0-0 || >=2.0.0 <3.0.0-0"},
+		{a: "^1", b: ">=2.0.0-0 <3", expected: ">=1.0.0 <3.0.0-0"},
+		{a: "^3", b: "^1", expected: ">=1.0.0 <2.0.0-0 || >=3.0.0 <4.0.0-0"},
+		{a: "<1.2.3", b: ">=1.2.3", expected: "*"},
+		{a: "<1.2.3", b: ">1.2.3", expected: "<1.2.3 || >1.2.3"},
+		{a: "<0.0.0-0", b: "<0.0.0-0", expected: "<0.0.0-0"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.a+" | "+testcase.b, func(t *testing.T) {
+			result := mustParseRange(t, testcase.a).Union(mustParseRange(t, testcase.b))
+			if s := result.String(); s != testcase.expected {
+				t.Fatalf("Expected %q, but got %q", testcase.expected, s)
+			}
+		})
+	}
+}
+
+func TestRangeComplement(t *testing.T) {
+	testcases := []struct {
+		constraint string
+		expected   string
+	}{
+		{constraint: "*", expected: "<0.0.0-0"},
+		{constraint: "<0.0.0-0", expected: "*"},
+		{constraint: "^1.2", expected: "<1.2.0 || >=2.0.0-0"},
+		{constraint: "1.2.3", expected: "<1.2.3 || >1.2.3"},
+		{constraint: ">=1.2.3", expected: "<1.2.3"},
+		{constraint: "<=1.2.3 || >2.0.0", expected: ">1.2.3 <=2.0.0"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.constraint, func(t *testing.T) {
+			r := mustParseRange(t, testcase.constraint)
+			complement := r.Complement()
+
+			if s := complement.String(); s != testcase.expected {
+				t.Fatalf("Expected %q, but got %q", testcase.expected, s)
+			}
+
+			if !r.Intersect(complement).IsEmpty() {
+				t.Fatalf("Range and its complement should not overlap.")
+			}
+
+			if s := r.Union(complement).String(); s != "*" {
+				t.Fatalf("Range and its complement should cover all versions, but got %q", s)
+			}
+		})
+	}
+}
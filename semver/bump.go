@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"fmt"
+	"strings"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+// Bump returns a new version with the given component incremented. Lower
+// components are reset and build metadata is always removed. Valid kinds are
+// "major", "minor", "patch", "prerelease" and "release" (which removes the
+// prerelease identifiers). The identifier is only used for prerelease bumps.
+//
+// Like npm's semver, bumping a prerelease finalizes it if the prerelease
+// already belongs to the next version of that kind, so a patch bump turns
+// 1.2.3-rc.1 into 1.2.3 and a major bump turns 2.0.0-rc.1 into 2.0.0.
+func Bump(v blangsemver.Version, kind string, identifier string) (blangsemver.Version, error) {
+	next := blangsemver.Version{
+		Major: v.Major,
+		Minor: v.Minor,
+		Patch: v.Patch,
+	}
+
+	isPre := len(v.Pre) > 0
+
+	switch kind {
+	case "major":
+		if !isPre || v.Minor != 0 || v.Patch != 0 {
+			next.Major++
+		}
+		next.Minor = 0
+		next.Patch = 0
+	case "minor":
+		if !isPre || v.Patch != 0 {
+			next.Minor++
+		}
+		next.Patch = 0
+	case "patch":
+		if !isPre {
+			next.Patch++
+		}
+	case "release":
+		if !isPre {
+			return next, fmt.Errorf("%s is not a prerelease", v)
+		}
+	case "prerelease":
+		identifiers, err := parsePrereleaseIdentifier(identifier)
+		if err != nil {
+			return next, err
+		}
+
+		next.Pre = bumpPrerelease(v.Pre, identifiers)
+
+		// 1.2.3 => 1.2.4-0
+		if !isPre {
+			next.Patch++
+		}
+
+		// 1.2.3-rc.1 => 1.2.3-beta.0 would go backwards
+		if next.Compare(v) <= 0 {
+			return next, fmt.Errorf("bumping %s to prerelease %q would result in the lower version %s", v, identifier, next)
+		}
+	default:
+		return next, fmt.Errorf("unknown kind %q, must be one of major, minor, patch, prerelease or release", kind)
+	}
+
+	if identifier != "" && kind != "prerelease" {
+		return next, fmt.Errorf("identifiers can only be used for prerelease bumps")
+	}
+
+	return next, nil
+}
+
+// parsePrereleaseIdentifier parses a (possibly dot-separated) prerelease
+// identifier like "rc" or "alpha.beta", so that it follows the same rules as
+// prerelease identifiers in parsed versions.
+func parsePrereleaseIdentifier(identifier string) ([]blangsemver.PRVersion, error) {
+	if identifier == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(identifier, ".")
+	result := make([]blangsemver.PRVersion, len(parts))
+
+	for i, part := range parts {
+		pr, err := blangsemver.NewPRVersion(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prerelease identifier %q: %w", identifier, err)
+		}
+
+		result[i] = pr
+	}
+
+	return result, nil
+}
+
+func bumpPrerelease(pre []blangsemver.PRVersion, identifiers []blangsemver.PRVersion) []blangsemver.PRVersion {
+	zero := blangsemver.PRVersion{VersionNum: 0, IsNum: true}
+
+	if len(identifiers) > 0 && !hasPrereleasePrefix(pre, identifiers) {
+		next := make([]blangsemver.PRVersion, len(identifiers), len(identifiers)+1)
+		copy(next, identifiers)
+
+		return append(next, zero)
+	}
+
+	if len(pre) == 0 {
+		return []blangsemver.PRVersion{zero}
+	}
+
+	next := make([]blangsemver.PRVersion, len(pre))
+	copy(next, pre)
+
+	// increment the last numeric identifier (rc.1.foo => rc.2.foo)
+	for i := len(next) - 1; i >= 0; i-- {
+		if next[i].IsNum {
+			next[i].VersionNum++
+			return next
+		}
+	}
+
+	// no numeric identifier found, so start counting (rc => rc.0)
+	return append(next, zero)
+}
+
+func hasPrereleasePrefix(pre []blangsemver.PRVersion, prefix []blangsemver.PRVersion) bool {
+	if len(pre) < len(prefix) {
+		return false
+	}
+
+	for i, p := range prefix {
+		if pre[i].Compare(p) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"fmt"
+	"testing"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+func TestBump(t *testing.T) {
+	testcases := []struct {
+		version    string
+		kind       string
+		identifier string
+		expected   string
+		invalid    bool
+	}{
+		{version: "1.2.3", kind: "major", expected: "2.0.0"},
+		{version: "1.2.3-rc.1+build", kind: "major", expected: "2.0.0"},
+		{version: "2.0.0-rc.1", kind: "major", expected: "2.0.0"},
+		{version: "2.1.0-rc.1", kind: "major", expected: "3.0.0"},
+		{version: "1.2.3", kind: "minor", expected: "1.3.0"},
+		{version: "1.3.0-rc.1", kind: "minor", expected: "1.3.0"},
+		{version: "1.3.1-rc.1", kind: "minor", expected: "1.4.0"},
+		{version: "1.2.3", kind: "patch", expected: "1.2.4"},
+		{version: "1.2.3-rc.1", kind: "patch", expected: "1.2.3"},
+		{version: "1.2.3+build", kind: "patch", expected: "1.2.4"},
+		{version: "1.2.3-rc.1", kind: "release", expected: "1.2.3"},
+		{version: "1.2.3", kind: "release", invalid: true},
+		{version: "1.2.3", kind: "prerelease", expected: "1.2.4-0"},
+		{version: "1.2.3", kind: "prerelease", identifier: "rc", expected: "1.2.4-rc.0"},
+		{version: "1.2.3-rc.1", kind: "prerelease", expected: "1.2.3-rc.2"},
+		{version: "1.2.3-rc.1", kind: "prerelease", identifier: "rc", expected: "1.2.3-rc.2"},
+		{version: "1.2.3-rc.1.foo", kind: "prerelease", expected: "1.2.3-rc.2.foo"},
+		{version: "1.2.3-rc", kind: "prerelease", expected: "1.2.3-rc.0"},
+		{version: "1.2.3-beta.4", kind: "prerelease", identifier: "rc", expected: "1.2.3-rc.0"},
+		{version: "1.2.3-rc.1", kind: "prerelease", identifier: "beta", invalid: true},
+		{version: "1.2.3", kind: "prerelease", identifier: "alpha.beta", expected: "1.2.4-alpha.beta.0"},
+		{version: "1.2.4-alpha.beta.0", kind: "prerelease", identifier: "alpha.beta", expected: "1.2.4-alpha.beta.1"},
+		{version: "1.2.3", kind: "prerelease", identifier: "1", expected: "1.2.4-1.0"},
+		{version: "1.2.4-1.0", kind: "prerelease", identifier: "1", expected: "1.2.4-1.1"},
+		{version: "1.2.4-1.0", kind: "prerelease", identifier: "2", expected: "1.2.4-2.0"},
+		{version: "1.2.3", kind: "prerelease", identifier: "rc 1", invalid: true},
+		{version: "1.2.3", kind: "prerelease", identifier: "01", invalid: true},
+		{version: "1.2.3", kind: "prerelease", identifier: "rc..1", invalid: true},
+		{version: "1.2.3", kind: "prerelease", identifier: "rc.", invalid: true},
+		{version: "1.2.3", kind: "major", identifier: "rc", invalid: true},
+		{version: "1.2.3", kind: "foo", invalid: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(fmt.Sprintf("%s %s %s", testcase.version, testcase.kind, testcase.identifier), func(t *testing.T) {
+			input := blangsemver.MustParse(testcase.version)
+
+			bumped, err := Bump(input, testcase.kind, testcase.identifier)
+			if err != nil {
+				if !testcase.invalid {
+					t.Fatalf("Failed to bump version: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.invalid {
+				t.Fatalf("Expected error, but got %s", bumped)
+			}
+
+			if bumped.String() != testcase.expected {
+				t.Fatalf("Expected %s, but got %s", testcase.expected, bumped)
+			}
+
+			// the result must be a valid version that compares like a parsed one
+			parsed, err := blangsemver.Parse(bumped.String())
+			if err != nil {
+				t.Fatalf("Bumped version %s cannot be parsed: %v", bumped, err)
+			}
+
+			if !parsed.Equals(bumped) {
+				t.Fatalf("Bumped version %s does not equal its parsed form", bumped)
+			}
+
+			if input.String() != testcase.version {
+				t.Fatalf("Input version was modified to %s", input)
+			}
+		})
+	}
+}
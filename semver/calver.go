@@ -0,0 +1,336 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.xrstf.de/rudi/pkg/coalescing"
+	"go.xrstf.de/rudi/pkg/deepcopy"
+	"go.xrstf.de/rudi/pkg/equality"
+)
+
+// Calver is a calendar version like "2024.10.1", described by a format like
+// "YYYY.MM.MICRO" (see https://calver.org/). Components that are not part of
+// the format are zero.
+type Calver struct {
+	Format   string
+	Year     int
+	Month    int
+	Week     int
+	Day      int
+	Major    int64
+	Minor    int64
+	Micro    int64
+	Modifier string
+}
+
+var (
+	_ deepcopy.Copier                  = Calver{}
+	_ coalescing.CustomStringCoalescer = Calver{}
+	_ equality.Comparer                = Calver{}
+)
+
+// calverTokens are sorted so that longer tokens are matched first.
+var calverTokens = []string{"YYYY", "MAJOR", "MINOR", "MICRO", "YY", "0Y", "MM", "0M", "WW", "0W", "DD", "0D"}
+
+// ParseCalver parses a version according to the given format. The format
+// consists of the tokens YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D, MAJOR, MINOR and
+// MICRO, separated by ".", "-" or "_". A modifier can follow the version,
+// separated by a "-" (e.g. "2023.12.0-beta").
+func ParseCalver(version string, format string) (Calver, error) {
+	tokens, err := tokenizeCalverFormat(format)
+	if err != nil {
+		return Calver{}, err
+	}
+
+	result := Calver{Format: format}
+	rest := version
+
+	for _, token := range tokens {
+		if !isCalverToken(token) {
+			if !strings.HasPrefix(rest, token) {
+				return Calver{}, fmt.Errorf("invalid calver %q: expected %q before %q", version, token, rest)
+			}
+
+			rest = rest[len(token):]
+			continue
+		}
+
+		digits := len(rest) - len(strings.TrimLeft(rest, "0123456789"))
+		if digits == 0 {
+			return Calver{}, fmt.Errorf("invalid calver %q: expected %s before %q", version, token, rest)
+		}
+
+		value := rest[:digits]
+		rest = rest[digits:]
+
+		if err := result.set(token, value); err != nil {
+			return Calver{}, fmt.Errorf("invalid calver %q: %w", version, err)
+		}
+	}
+
+	if rest != "" {
+		if !strings.HasPrefix(rest, "-") || len(rest) == 1 {
+			return Calver{}, fmt.Errorf("invalid calver %q: unexpected %q", version, rest)
+		}
+
+		result.Modifier = rest[1:]
+	}
+
+	if err := result.validateDate(); err != nil {
+		return Calver{}, fmt.Errorf("invalid calver %q: %w", version, err)
+	}
+
+	return result, nil
+}
+
+func tokenizeCalverFormat(format string) ([]string, error) {
+	tokens := []string{}
+	rest := format
+
+	for rest != "" {
+		if strings.ContainsAny(rest[:1], ".-_") {
+			tokens = append(tokens, rest[:1])
+			rest = rest[1:]
+			continue
+		}
+
+		found := false
+		for _, token := range calverTokens {
+			if strings.HasPrefix(rest, token) {
+				tokens = append(tokens, token)
+				rest = rest[len(token):]
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("invalid calver format %q: unknown token at %q", format, rest)
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("invalid calver format %q: no tokens", format)
+	}
+
+	return tokens, nil
+}
+
+func isCalverToken(s string) bool {
+	for _, token := range calverTokens {
+		if s == token {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Calver) set(token string, value string) error {
+	padded := strings.HasPrefix(token, "0")
+
+	switch {
+	case token == "YYYY" && len(value) != 4:
+		return fmt.Errorf("%s must have 4 digits, got %q", token, value)
+	case padded && len(value) < 2:
+		return fmt.Errorf("%s must have at least 2 digits, got %q", token, value)
+	case padded && token != "0Y" && len(value) > 2:
+		return fmt.Errorf("%s must have 2 digits, got %q", token, value)
+	case !padded && len(value) > 1 && value[0] == '0':
+		return fmt.Errorf("%s must not have leading zeros, got %q", token, value)
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", token, err)
+	}
+
+	switch token {
+	case "YYYY":
+		c.Year = int(n)
+	case "YY", "0Y":
+		c.Year = 2000 + int(n)
+	case "MM", "0M":
+		c.Month = int(n)
+	case "WW", "0W":
+		c.Week = int(n)
+	case "DD", "0D":
+		c.Day = int(n)
+	case "MAJOR":
+		c.Major = n
+	case "MINOR":
+		c.Minor = n
+	case "MICRO":
+		c.Micro = n
+	}
+
+	return nil
+}
+
+// hasToken returns true if the format contains any of the given tokens.
+func (c Calver) hasToken(tokens ...string) bool {
+	formatTokens, _ := tokenizeCalverFormat(c.Format)
+
+	for _, formatToken := range formatTokens {
+		for _, token := range tokens {
+			if formatToken == token {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c Calver) validateDate() error {
+	if c.hasToken("MM", "0M") && (c.Month < 1 || c.Month > 12) {
+		return fmt.Errorf("invalid month %d", c.Month)
+	}
+
+	if c.hasToken("WW", "0W") && (c.Week < 1 || c.Week > 53) {
+		return fmt.Errorf("invalid week %d", c.Week)
+	}
+
+	if c.hasToken("DD", "0D") {
+		month := c.Month
+		if month == 0 {
+			month = 1
+		}
+
+		date := time.Date(c.Year, time.Month(month), c.Day, 0, 0, 0, 0, time.UTC)
+		if c.Day == 0 || date.Day() != c.Day {
+			return fmt.Errorf("invalid day %d", c.Day)
+		}
+	}
+
+	return nil
+}
+
+// Date returns the date that the version refers to. Components that are not
+// part of the format default to the first month/day. If the format contains
+// a week, the Monday of that (ISO) week is returned.
+func (c Calver) Date() (time.Time, error) {
+	if !c.hasToken("YYYY", "YY", "0Y") {
+		return time.Time{}, fmt.Errorf("calver format %q does not contain a year", c.Format)
+	}
+
+	if c.Week > 0 {
+		// January 4th is always in the first ISO week
+		jan4 := time.Date(c.Year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		offset := (int(jan4.Weekday()) + 6) % 7
+
+		return jan4.AddDate(0, 0, (c.Week-1)*7-offset), nil
+	}
+
+	month := c.Month
+	if month == 0 {
+		month = 1
+	}
+
+	day := c.Day
+	if day == 0 {
+		day = 1
+	}
+
+	return time.Date(c.Year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
+
+func (c Calver) String() string {
+	tokens, err := tokenizeCalverFormat(c.Format)
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	for _, token := range tokens {
+		switch token {
+		case "YYYY", "MAJOR", "MINOR", "MICRO", "MM", "WW", "DD":
+			sb.WriteString(strconv.FormatInt(c.component(token), 10))
+		case "YY":
+			sb.WriteString(strconv.Itoa(c.Year - 2000))
+		case "0Y", "0M", "0W", "0D":
+			sb.WriteString(fmt.Sprintf("%02d", c.component(token)))
+		default:
+			sb.WriteString(token)
+		}
+	}
+
+	if c.Modifier != "" {
+		sb.WriteString("-")
+		sb.WriteString(c.Modifier)
+	}
+
+	return sb.String()
+}
+
+func (c Calver) component(token string) int64 {
+	switch token {
+	case "YYYY":
+		return int64(c.Year)
+	case "0Y":
+		return int64(c.Year - 2000)
+	case "MM", "0M":
+		return int64(c.Month)
+	case "WW", "0W":
+		return int64(c.Week)
+	case "DD", "0D":
+		return int64(c.Day)
+	case "MAJOR":
+		return c.Major
+	case "MINOR":
+		return c.Minor
+	default:
+		return c.Micro
+	}
+}
+
+// DeepCopy implements deepcopy.Copier.
+func (c Calver) DeepCopy() (any, error) {
+	return c, nil
+}
+
+// CoalesceToString implements coalescing.CustomStringCoalescer.
+func (c Calver) CoalesceToString(_ coalescing.Coalescer) (string, error) {
+	return c.String(), nil
+}
+
+// Compare implements equality.Comparer. Calvers are compared by year,
+// month, week, day, major, minor and micro version (regardless of their
+// format). A version with a modifier is considered smaller than the same
+// version without one (i.e. "2023.12.0-beta" is older than "2023.12.0").
+func (c Calver) Compare(other any) (int, error) {
+	otherC, ok := other.(Calver)
+	if !ok {
+		return 0, equality.ErrIncompatibleTypes
+	}
+
+	a := []int64{int64(c.Year), int64(c.Month), int64(c.Week), int64(c.Day), c.Major, c.Minor, c.Micro}
+	b := []int64{int64(otherC.Year), int64(otherC.Month), int64(otherC.Week), int64(otherC.Day), otherC.Major, otherC.Minor, otherC.Micro}
+
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1, nil
+		case a[i] > b[i]:
+			return 1, nil
+		}
+	}
+
+	switch {
+	case c.Modifier == otherC.Modifier:
+		return 0, nil
+	case c.Modifier == "":
+		return 1, nil
+	case otherC.Modifier == "":
+		return -1, nil
+	default:
+		return strings.Compare(c.Modifier, otherC.Modifier), nil
+	}
+}
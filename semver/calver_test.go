@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"testing"
+)
+
+func TestParseCalver(t *testing.T) {
+	testcases := []struct {
+		version string
+		format  string
+		date    string
+		invalid bool
+	}{
+		{version: "2024.10.1", format: "YYYY.MM.MICRO", date: "2024-10-01"},
+		{version: "2024.4.0", format: "YYYY.MM.MICRO", date: "2024-04-01"},
+		{version: "2024.04.0", format: "YYYY.MM.MICRO", invalid: true},
+		{version: "24.04", format: "YY.0M", date: "2024-04-01"},
+		{version: "24.4", format: "YY.0M", invalid: true},
+		{version: "24.13", format: "YY.0M", invalid: true},
+		{version: "2023.12.0-beta", format: "YYYY.0M.MICRO", date: "2023-12-01"},
+		{version: "2023.12.0-", format: "YYYY.0M.MICRO", invalid: true},
+		{version: "2023.12.0.1", format: "YYYY.0M.MICRO", invalid: true},
+		{version: "2024-02-29", format: "YYYY-0M-0D", date: "2024-02-29"},
+		{version: "2023-02-29", format: "YYYY-0M-0D", invalid: true},
+		{version: "2024.01", format: "YYYY.0W", date: "2024-01-01"},
+		{version: "2021.01", format: "YYYY.0W", date: "2021-01-04"},
+		{version: "2021.54", format: "YYYY.0W", invalid: true},
+		{version: "1.2.3", format: "MAJOR.MINOR.MICRO", invalid: false},
+		{version: "2024.10", format: "YYYY.FOO", invalid: true},
+		{version: "foo", format: "YYYY", invalid: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.format+" "+testcase.version, func(t *testing.T) {
+			parsed, err := ParseCalver(testcase.version, testcase.format)
+			if err != nil {
+				if !testcase.invalid {
+					t.Fatalf("Failed to parse calver: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.invalid {
+				t.Fatalf("Expected error, but got %#v", parsed)
+			}
+
+			if s := parsed.String(); s != testcase.version {
+				t.Errorf("Expected %q, but String() returned %q", testcase.version, s)
+			}
+
+			date, err := parsed.Date()
+			if err != nil {
+				if testcase.date != "" {
+					t.Fatalf("Failed to convert to date: %v", err)
+				}
+
+				return
+			}
+
+			if s := date.Format("2006-01-02"); s != testcase.date {
+				t.Errorf("Expected date %s, but got %s", testcase.date, s)
+			}
+		})
+	}
+}
+
+func TestCompareCalver(t *testing.T) {
+	testcases := []struct {
+		a, aFormat string
+		b, bFormat string
+		expected   int
+	}{
+		{a: "2024.10.1", aFormat: "YYYY.MM.MICRO", b: "2024.10.1", bFormat: "YYYY.MM.MICRO", expected: 0},
+		{a: "2024.10.1", aFormat: "YYYY.MM.MICRO", b: "2024.9.3", bFormat: "YYYY.MM.MICRO", expected: 1},
+		{a: "2024.10.1", aFormat: "YYYY.MM.MICRO", b: "2024.10.2", bFormat: "YYYY.MM.MICRO", expected: -1},
+		{a: "2023.12.0-beta", aFormat: "YYYY.0M.MICRO", b: "2023.12.0", bFormat: "YYYY.0M.MICRO", expected: -1},
+		{a: "2023.12.0-beta", aFormat: "YYYY.0M.MICRO", b: "2023.12.0-alpha", bFormat: "YYYY.0M.MICRO", expected: 1},
+		{a: "24.04", aFormat: "YY.0M", b: "2024.4", bFormat: "YYYY.MM", expected: 0},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.a+" "+testcase.b, func(t *testing.T) {
+			a, err := ParseCalver(testcase.a, testcase.aFormat)
+			if err != nil {
+				t.Fatalf("Failed to parse calver: %v", err)
+			}
+
+			b, err := ParseCalver(testcase.b, testcase.bFormat)
+			if err != nil {
+				t.Fatalf("Failed to parse calver: %v", err)
+			}
+
+			result, err := a.Compare(b)
+			if err != nil {
+				t.Fatalf("Failed to compare: %v", err)
+			}
+
+			if result != testcase.expected {
+				t.Fatalf("Expected %d, but got %d", testcase.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.xrstf.de/rudi/pkg/coalescing"
+	"go.xrstf.de/rudi/pkg/deepcopy"
+	"go.xrstf.de/rudi/pkg/equality"
+)
+
+// DebianVersion is a Debian package version like "1:2.3-4ubuntu1", consisting
+// of an optional epoch, the upstream version and an optional revision.
+type DebianVersion struct {
+	Epoch    int64
+	Upstream string
+	Revision string
+}
+
+var (
+	_ deepcopy.Copier                  = DebianVersion{}
+	_ coalescing.CustomStringCoalescer = DebianVersion{}
+	_ equality.Comparer                = DebianVersion{}
+)
+
+var (
+	debianUpstreamRegex = regexp.MustCompile(`^[0-9][A-Za-z0-9.+~:-]*$`)
+	debianRevisionRegex = regexp.MustCompile(`^[A-Za-z0-9.+~]+$`)
+)
+
+// ParseDebianVersion parses a version as described in the Debian policy
+// manual, section 5.6.12.
+func ParseDebianVersion(version string) (DebianVersion, error) {
+	result := DebianVersion{}
+	rest := strings.TrimSpace(version)
+
+	if idx := strings.IndexByte(rest, ':'); idx != -1 {
+		epoch, err := strconv.ParseInt(rest[:idx], 10, 64)
+		if err != nil || epoch < 0 {
+			return DebianVersion{}, fmt.Errorf("invalid Debian version %q: epoch must be a positive number", version)
+		}
+
+		result.Epoch = epoch
+		rest = rest[idx+1:]
+	}
+
+	if idx := strings.LastIndexByte(rest, '-'); idx != -1 {
+		result.Revision = rest[idx+1:]
+		rest = rest[:idx]
+
+		if !debianRevisionRegex.MatchString(result.Revision) {
+			return DebianVersion{}, fmt.Errorf("invalid Debian version %q: invalid revision %q", version, result.Revision)
+		}
+	}
+
+	if !debianUpstreamRegex.MatchString(rest) {
+		return DebianVersion{}, fmt.Errorf("invalid Debian version %q: upstream version %q must start with a digit and only contain [A-Za-z0-9.+~:-]", version, rest)
+	}
+
+	result.Upstream = rest
+
+	return result, nil
+}
+
+func (v DebianVersion) String() string {
+	var sb strings.Builder
+
+	if v.Epoch != 0 {
+		sb.WriteString(fmt.Sprintf("%d:", v.Epoch))
+	}
+
+	sb.WriteString(v.Upstream)
+
+	if v.Revision != "" {
+		sb.WriteString("-")
+		sb.WriteString(v.Revision)
+	}
+
+	return sb.String()
+}
+
+// DeepCopy implements deepcopy.Copier.
+func (v DebianVersion) DeepCopy() (any, error) {
+	return v, nil
+}
+
+// CoalesceToString implements coalescing.CustomStringCoalescer.
+func (v DebianVersion) CoalesceToString(_ coalescing.Coalescer) (string, error) {
+	return v.String(), nil
+}
+
+// Compare implements equality.Comparer, using the same algorithm as dpkg.
+func (v DebianVersion) Compare(other any) (int, error) {
+	otherV, ok := other.(DebianVersion)
+	if !ok {
+		return 0, equality.ErrIncompatibleTypes
+	}
+
+	if c := compareInt64(v.Epoch, otherV.Epoch); c != 0 {
+		return c, nil
+	}
+
+	if c := compareDebianStrings(v.Upstream, otherV.Upstream); c != 0 {
+		return c, nil
+	}
+
+	return compareDebianStrings(v.Revision, otherV.Revision), nil
+}
+
+// debianOrder returns the sort weight of a non-digit character: "~" sorts
+// before everything (even the end of the string), letters sort before
+// non-letters.
+func debianOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+
+	c := s[i]
+
+	switch {
+	case isDigit(c):
+		return 0
+	case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		return int(c)
+	case c == '~':
+		return -1
+	default:
+		return int(c) + 256
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// compareDebianStrings implements dpkg's verrevcmp.
+func compareDebianStrings(a, b string) int {
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		firstDiff := 0
+
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			ac := debianOrder(a, i)
+			bc := debianOrder(b, j)
+
+			if ac != bc {
+				return sign(ac - bc)
+			}
+
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+
+			i++
+			j++
+		}
+
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+
+		if firstDiff != 0 {
+			return sign(firstDiff)
+		}
+	}
+
+	return 0
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"testing"
+)
+
+func TestParseDebianVersion(t *testing.T) {
+	testcases := []struct {
+		version  string
+		expected DebianVersion
+		invalid  bool
+	}{
+		{version: "1.0", expected: DebianVersion{Upstream: "1.0"}},
+		{version: "1:2.3-4ubuntu1", expected: DebianVersion{Epoch: 1, Upstream: "2.3", Revision: "4ubuntu1"}},
+		{version: "2.3-rc1-4", expected: DebianVersion{Upstream: "2.3-rc1", Revision: "4"}},
+		{version: "1:2.3:4-5", expected: DebianVersion{Epoch: 1, Upstream: "2.3:4", Revision: "5"}},
+		{version: "1.0~rc1", expected: DebianVersion{Upstream: "1.0~rc1"}},
+		{version: "", invalid: true},
+		{version: "a1.0", invalid: true},
+		{version: "x:1.0", invalid: true},
+		{version: "1.0-", invalid: true},
+		{version: "1.0-a_b", invalid: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.version, func(t *testing.T) {
+			parsed, err := ParseDebianVersion(testcase.version)
+			if err != nil {
+				if !testcase.invalid {
+					t.Fatalf("Failed to parse version: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.invalid {
+				t.Fatalf("Expected error, but got %s", parsed)
+			}
+
+			if parsed != testcase.expected {
+				t.Fatalf("Expected %#v, but got %#v", testcase.expected, parsed)
+			}
+
+			if parsed.String() != testcase.version {
+				t.Fatalf("Expected %q, but String() returned %q", testcase.version, parsed.String())
+			}
+		})
+	}
+}
+
+func TestCompareDebianVersions(t *testing.T) {
+	testcases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "1.0", b: "1.0", expected: 0},
+		{a: "1.0", b: "1.00", expected: 0},
+		{a: "1.0", b: "1.0-0", expected: 0},
+		{a: "1.0", b: "1.1", expected: -1},
+		{a: "1.10", b: "1.9", expected: 1},
+		{a: "1:1.0", b: "2.0", expected: 1},
+		{a: "1.0~rc1", b: "1.0", expected: -1},
+		{a: "1.0~~", b: "1.0~", expected: -1},
+		{a: "1.0~", b: "1.0~a", expected: -1},
+		{a: "1.0a", b: "1.0", expected: 1},
+		{a: "1.0.", b: "1.0+", expected: 1},
+		{a: "1.0.", b: "1.0+", expected: 1},
+		{a: "2.3-4ubuntu1", b: "2.3-4", expected: 1},
+		{a: "2.3-4ubuntu1", b: "2.3-4ubuntu2", expected: -1},
+		{a: "2.3-10", b: "2.3-9", expected: 1},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.a+" "+testcase.b, func(t *testing.T) {
+			a, err := ParseDebianVersion(testcase.a)
+			if err != nil {
+				t.Fatalf("Failed to parse %q: %v", testcase.a, err)
+			}
+
+			b, err := ParseDebianVersion(testcase.b)
+			if err != nil {
+				t.Fatalf("Failed to parse %q: %v", testcase.b, err)
+			}
+
+			result, err := a.Compare(b)
+			if err != nil {
+				t.Fatalf("Failed to compare: %v", err)
+			}
+
+			if result != testcase.expected {
+				t.Fatalf("Expected %d, but got %d", testcase.expected, result)
+			}
+
+			// comparing must be symmetric
+			result, _ = b.Compare(a)
+			if result != -testcase.expected {
+				t.Fatalf("Expected reverse comparison to return %d, but got %d", -testcase.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"strings"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+// Difference returns the most significant component in which the two versions
+// differ, one of "major", "minor", "patch", "prerelease", "build" or "none".
+func Difference(a, b blangsemver.Version) string {
+	switch {
+	case a.Major != b.Major:
+		return "major"
+	case a.Minor != b.Minor:
+		return "minor"
+	case a.Patch != b.Patch:
+		return "patch"
+	case !equalPrereleases(a.Pre, b.Pre):
+		return "prerelease"
+	case strings.Join(a.Build, ".") != strings.Join(b.Build, "."):
+		return "build"
+	default:
+		return "none"
+	}
+}
+
+func equalPrereleases(a, b []blangsemver.PRVersion) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Compare(b[i]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsBreaking returns true if changing from one version to the other is a
+// breaking change under semver rules. This is the case if the left-most
+// non-zero component differs: For 1.x versions this is the major version,
+// for 0.x versions it is the minor version and for 0.0.x versions it is the
+// patch version. Changes to prerelease identifiers or build metadata are not
+// considered breaking.
+func IsBreaking(from, to blangsemver.Version) bool {
+	switch {
+	case from.Major != to.Major:
+		return true
+	case from.Major > 0:
+		return false
+	case from.Minor != to.Minor:
+		return true
+	case from.Minor > 0:
+		return false
+	default:
+		return from.Patch != to.Patch
+	}
+}
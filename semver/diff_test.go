@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"testing"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+func TestDifference(t *testing.T) {
+	testcases := []struct {
+		a, b     string
+		diff     string
+		breaking bool
+	}{
+		{a: "1.2.3", b: "1.2.3", diff: "none"},
+		{a: "1.2.3", b: "2.0.0", diff: "major", breaking: true},
+		{a: "2.0.0", b: "1.2.3", diff: "major", breaking: true},
+		{a: "1.2.3", b: "1.3.0", diff: "minor"},
+		{a: "1.2.3", b: "1.2.4", diff: "patch"},
+		{a: "1.2.3-rc.1", b: "1.2.3", diff: "prerelease"},
+		{a: "1.2.3-rc.1", b: "1.2.3-rc.2", diff: "prerelease"},
+		{a: "1.2.3+abc", b: "1.2.3", diff: "build"},
+		{a: "1.2.3-rc.1+abc", b: "1.2.3-rc.1+abc", diff: "none"},
+		{a: "0.2.3", b: "0.3.0", diff: "minor", breaking: true},
+		{a: "0.2.3", b: "0.2.4", diff: "patch"},
+		{a: "0.0.3", b: "0.0.4", diff: "patch", breaking: true},
+		{a: "0.0.3", b: "0.1.0", diff: "minor", breaking: true},
+		{a: "0.2.3", b: "1.0.0", diff: "major", breaking: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.a+" "+testcase.b, func(t *testing.T) {
+			a := blangsemver.MustParse(testcase.a)
+			b := blangsemver.MustParse(testcase.b)
+
+			if diff := Difference(a, b); diff != testcase.diff {
+				t.Errorf("Expected difference %q, but got %q", testcase.diff, diff)
+			}
+
+			if breaking := IsBreaking(a, b); breaking != testcase.breaking {
+				t.Errorf("Expected breaking=%v, but got %v", testcase.breaking, breaking)
+			}
+		})
+	}
+}
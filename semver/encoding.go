@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"encoding"
+	"fmt"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+var (
+	_ encoding.TextMarshaler   = Semver{}
+	_ encoding.TextUnmarshaler = &Semver{}
+	_ encoding.TextMarshaler   = Range{}
+	_ encoding.TextUnmarshaler = &Range{}
+)
+
+// MarshalText implements encoding.TextMarshaler, which is also used when
+// encoding a semver as JSON or YAML.
+func (v Semver) MarshalText() ([]byte, error) {
+	return []byte(v.Version.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is parsed in
+// the same relaxed way as the semver function does.
+func (v *Semver) UnmarshalText(text []byte) error {
+	parsed, err := blangsemver.ParseTolerant(string(text))
+	if err != nil {
+		return err
+	}
+
+	v.Version = parsed
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, which is also used when
+// encoding a range as JSON or YAML.
+func (r Range) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Range) UnmarshalText(text []byte) error {
+	parsed, err := ParseRange(string(text))
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+
+	return nil
+}
+
+// DecodeSemver turns a decoded string into a Semver. It is meant to be used as
+// a hook for decoders, like a tag decoder for the yaml module:
+//
+//	yaml.RegisterTagDecoder("!semver", semver.DecodeSemver)
+func DecodeSemver(value any) (any, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode %T as a semver", value)
+	}
+
+	var v Semver
+	if err := v.UnmarshalText([]byte(str)); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// DecodeRange turns a decoded string into a Range, see DecodeSemver.
+func DecodeRange(value any) (any, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode %T as a range", value)
+	}
+
+	return ParseRange(str)
+}
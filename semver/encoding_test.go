@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+
+	blangsemver "github.com/blang/semver/v4"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func TestSemverJSONRoundtrip(t *testing.T) {
+	type document struct {
+		Version Semver `json:"version"`
+		Range   Range  `json:"range"`
+	}
+
+	input := map[string]any{
+		"version": Semver{Version: blangsemver.MustParse("1.2.3-rc.1+abc")},
+		"range":   Range{Sets: [][]Comparator{{{Operator: OpGreaterEqual, Version: blangsemver.MustParse("1.2.0")}}}},
+	}
+
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+
+	expected := `{"range":"\u003e=1.2.0","version":"1.2.3-rc.1+abc"}`
+	if string(encoded) != expected {
+		t.Fatalf("Expected %s, but got %s", expected, string(encoded))
+	}
+
+	var decoded document
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+
+	if decoded.Version.Version.String() != "1.2.3-rc.1+abc" {
+		t.Errorf("Expected version 1.2.3-rc.1+abc, but got %s", decoded.Version.Version)
+	}
+
+	if decoded.Range.String() != ">=1.2.0" {
+		t.Errorf("Expected range >=1.2.0, but got %s", decoded.Range)
+	}
+
+	if err := json.Unmarshal([]byte(`{"version":"foo"}`), &decoded); err == nil {
+		t.Error("Expected error when decoding invalid version.")
+	}
+}
+
+func TestDecodeSemver(t *testing.T) {
+	decoded, err := DecodeSemver("v1.2")
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+
+	v, ok := decoded.(Semver)
+	if !ok {
+		t.Fatalf("Expected Semver, but got %T", decoded)
+	}
+
+	if v.Version.String() != "1.2.0" {
+		t.Fatalf("Expected 1.2.0, but got %s", v.Version)
+	}
+
+	if _, err := DecodeSemver(42); err == nil {
+		t.Fatal("Expected error when decoding non-string.")
+	}
+}
+
+func TestSemverYAMLRoundtrip(t *testing.T) {
+	type document struct {
+		Version Semver `yaml:"version"`
+		Range   Range  `yaml:"range"`
+	}
+
+	input := document{
+		Version: Semver{Version: blangsemver.MustParse("1.2.3-rc.1+abc")},
+		Range:   Range{Sets: [][]Comparator{{{Operator: OpGreaterEqual, Version: blangsemver.MustParse("1.2.0")}}}},
+	}
+
+	encoded, err := yamlv3.Marshal(input)
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+
+	expected := "version: 1.2.3-rc.1+abc\nrange: '>=1.2.0'\n"
+	if string(encoded) != expected {
+		t.Fatalf("Expected\n%s\nbut got\n%s", expected, string(encoded))
+	}
+
+	var decoded document
+	if err := yamlv3.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+
+	if decoded.Version.Version.String() != "1.2.3-rc.1+abc" {
+		t.Errorf("Expected version 1.2.3-rc.1+abc, but got %s", decoded.Version.Version)
+	}
+
+	if decoded.Range.String() != ">=1.2.0" {
+		t.Errorf("Expected range >=1.2.0, but got %s", decoded.Range)
+	}
+}
+
+func TestDecodeTaggedYAML(t *testing.T) {
+	// this is what the yaml module does for nodes with registered tags
+	var doc struct {
+		Version yamlv3.Node `yaml:"version"`
+		Range   yamlv3.Node `yaml:"range"`
+	}
+
+	if err := yamlv3.Unmarshal([]byte("version: !semver v1.2\nrange: !semver-range ^1.2"), &doc); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+
+	version, err := DecodeSemver(doc.Version.Value)
+	if err != nil {
+		t.Fatalf("Failed to decode semver: %v", err)
+	}
+
+	if v := version.(Semver).Version.String(); v != "1.2.0" {
+		t.Errorf("Expected version 1.2.0, but got %s", v)
+	}
+
+	r, err := DecodeRange(doc.Range.Value)
+	if err != nil {
+		t.Fatalf("Failed to decode range: %v", err)
+	}
+
+	if !r.(Range).Satisfies(blangsemver.MustParse("1.9.0")) {
+		t.Errorf("Expected range %s to be satisfied by 1.9.0", r)
+	}
+}
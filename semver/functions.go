@@ -4,14 +4,65 @@
 package semver
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	blangsemver "github.com/blang/semver/v4"
 
 	"go.xrstf.de/rudi"
+	"go.xrstf.de/rudi/pkg/runtime/types"
 )
 
 var (
 	Functions = rudi.Functions{
-		"semver": rudi.NewFunctionBuilder(parseFunction).WithDescription("parses a string as a semantic version").Build(),
+		"calver":                  rudi.NewFunctionBuilder(parseCalverFunction).WithDescription("parses a string as a calendar version using the given format").Build(),
+		"calver-date":             rudi.NewFunctionBuilder(calverDateFunction).WithDescription("returns the date (YYYY-MM-DD) a calendar version refers to").Build(),
+		"debian-version":          rudi.NewFunctionBuilder(parseDebianVersionFunction).WithDescription("parses a string as a Debian package version").Build(),
+		"new-semver-map":          rudi.NewFunctionBuilder(newEmptyVersionMapFunction, newVersionMapFunction).WithDescription("creates a map with semvers as keys, sorted by version").Build(),
+		"pep440":                  rudi.NewFunctionBuilder(parsePEP440Function).WithDescription("parses a string as a Python package version (PEP 440)").Build(),
+		"semver":                  rudi.NewFunctionBuilder(parseFunction).WithDescription("parses a string as a semantic version").Build(),
+		"semver-bump":             rudi.NewFunctionBuilder(bumpFunction, bumpPrereleaseFunction).WithDescription("returns a new semver with the given component incremented").Build(),
+		"semver-breaking?":        rudi.NewFunctionBuilder(isBreakingFunction).WithDescription("returns true if changing between the two versions is a breaking change").Build(),
+		"semver-comparable":       rudi.NewFunctionBuilder(parseComparableFunction).WithDescription("parses a string as a semantic version that can be compared to strings").Build(),
+		"semver-diff":             rudi.NewFunctionBuilder(diffFunction).WithDescription("returns the most significant component in which two versions differ").Build(),
+		"semver-go-compare":       rudi.NewFunctionBuilder(goCompareFunction).WithDescription("compares two versions using the Go module ordering rules").Build(),
+		"semver-go-incompatible?": rudi.NewFunctionBuilder(goIncompatibleFunction).WithDescription("returns true if the version is marked as +incompatible").Build(),
+		"semver-go-major-suffix":  rudi.NewFunctionBuilder(goMajorSuffixFunction).WithDescription("returns the major version suffix (e.g. /v2) for Go module paths").Build(),
+		"semver-go-pseudo?":       rudi.NewFunctionBuilder(goPseudoFunction).WithDescription("returns true if the string is a Go module pseudo-version").Build(),
+		"semver-go-pseudo-rev":    rudi.NewFunctionBuilder(goPseudoRevFunction).WithDescription("returns the revision identifier of a Go module pseudo-version").Build(),
+		"semver-go-pseudo-time":   rudi.NewFunctionBuilder(goPseudoTimeFunction).WithDescription("returns the timestamp of a Go module pseudo-version").Build(),
+		"semver-invalid":          rudi.NewFunctionBuilder(invalidFunction).WithDescription("returns all entries of a vector that are not valid semvers").Build(),
+		"semver-latest":           rudi.NewFunctionBuilder(latestFunction, latestInRangeFunction, latestInRangeWithPrereleasesFunction).WithDescription("returns the newest stable version from a vector, optionally matching a range").Build(),
+		"semver-map-delete":       rudi.NewFunctionBuilder(versionMapDeleteFunction).WithDescription("returns a copy of the version map with the given versions removed").Build(),
+		"semver-map-entries":      rudi.NewFunctionBuilder(versionMapEntriesFunction).WithDescription("returns all [version value] pairs of a version map, sorted by version").Build(),
+		"semver-map-get":          rudi.NewFunctionBuilder(versionMapGetFunction, versionMapGetDefaultFunction).WithDescription("returns the value stored for a version in a version map").Build(),
+		"semver-map-keys":         rudi.NewFunctionBuilder(versionMapKeysFunction).WithDescription("returns all versions of a version map in ascending order").Build(),
+		"semver-map-set":          rudi.NewFunctionBuilder(versionMapSetFunction).WithDescription("returns a copy of the version map with the value stored for the given version").Build(),
+		"semver-map-size":         rudi.NewFunctionBuilder(versionMapSizeFunction).WithDescription("returns the number of entries in a version map").Build(),
+		"semver-map-values":       rudi.NewFunctionBuilder(versionMapValuesFunction).WithDescription("returns all values of a version map, sorted by version").Build(),
+		"semver-max":              rudi.NewFunctionBuilder(maxFunction, maxStableFunction).WithDescription("returns the highest version from a vector").Build(),
+		"semver-min":              rudi.NewFunctionBuilder(minFunction, minStableFunction).WithDescription("returns the lowest version from a vector").Build(),
+		"semver-sort":             rudi.NewFunctionBuilder(sortFunction, sortStableFunction).WithDescription("returns a copy of the vector, sorted by semantic version").Build(),
+		"semver-prerelease?":      rudi.NewFunctionBuilder(isPrereleaseFunction).WithDescription("returns true if the semver has prerelease identifiers").Build(),
+		"semver-set-build":        rudi.NewFunctionBuilder(setBuildFunction).WithDescription("returns a copy of the semver with the given build metadata").Build(),
+		"semver-set-pre":          rudi.NewFunctionBuilder(setPrereleaseFunction).WithDescription("returns a copy of the semver with the given prerelease identifiers").Build(),
+		"semver-strip-build":      rudi.NewFunctionBuilder(stripBuildFunction).WithDescription("returns a copy of the semver without build metadata").Build(),
+		"semver-strip-pre":        rudi.NewFunctionBuilder(stripPrereleaseFunction).WithDescription("returns a copy of the semver without prerelease identifiers").Build(),
+		"semver-strict":           rudi.NewFunctionBuilder(parseStrictFunction).WithDescription("parses a string as a semantic version, strictly following the specification").Build(),
+		"semver-valid?":           rudi.NewFunctionBuilder(isValidFunction).WithDescription("returns true if the value is a strictly valid semantic version").Build(),
+		"semver-range":            rudi.NewFunctionBuilder(parseRangeFunction, parseRangeDialectFunction).WithDescription("parses a string as a version range").Build(),
+		"semver-range-complement": rudi.NewFunctionBuilder(rangeComplementFunction).WithDescription("returns a range matching all versions not matched by the given range").Build(),
+		"semver-range-empty?":     rudi.NewFunctionBuilder(rangeEmptyFunction).WithDescription("returns true if no version can satisfy the range").Build(),
+		"semver-range-intersect":  rudi.NewFunctionBuilder(rangeIntersectFunction).WithDescription("returns a range matching all versions matched by both ranges").Build(),
+		"semver-range-string":     rudi.NewFunctionBuilder(formatRangeFunction).WithDescription("formats a version range in the given dialect").Build(),
+		"semver-range-union":      rudi.NewFunctionBuilder(rangeUnionFunction).WithDescription("returns a range matching all versions matched by either range").Build(),
+		"semver-satisfies?":       rudi.NewFunctionBuilder(satisfiesFunction).WithDescription("returns true if the version satisfies the range").Build(),
+		"semver-minor-steps":      rudi.NewFunctionBuilder(minorStepsFunction).WithDescription("returns all minor versions between two versions for a stepwise upgrade").Build(),
+		"semver-skew-ok?":         rudi.NewFunctionBuilder(skewOKFunction, skewOKWithPolicyFunction).WithDescription("returns true if all component versions satisfy the Kubernetes version skew policy").Build(),
+		"semver-skew-violations":  rudi.NewFunctionBuilder(skewViolationsFunction, skewViolationsWithPolicyFunction).WithDescription("returns all violations of the Kubernetes version skew policy").Build(),
 	}
 )
 
@@ -25,3 +76,739 @@ func parseFunction(version string) (any, error) {
 		Version: parsed,
 	}, nil
 }
+
+func parseComparableFunction(version string) (any, error) {
+	parsed, err := blangsemver.ParseTolerant(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return Semver{
+		Version:            parsed,
+		CompareWithStrings: true,
+	}, nil
+}
+
+func parseCalverFunction(version string, format string) (any, error) {
+	return ParseCalver(version, format)
+}
+
+func calverDateFunction(version any) (any, error) {
+	c, ok := version.(Calver)
+	if !ok {
+		return nil, fmt.Errorf("argument #0: not a calver, but %T", version)
+	}
+
+	date, err := c.Date()
+	if err != nil {
+		return nil, err
+	}
+
+	return date.Format("2006-01-02"), nil
+}
+
+func parsePEP440Function(version string) (any, error) {
+	return ParsePEP440(version)
+}
+
+func parseDebianVersionFunction(version string) (any, error) {
+	return ParseDebianVersion(version)
+}
+
+func parseStrictFunction(version string) (any, error) {
+	parsed, err := ParseStrict(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return Semver{
+		Version: parsed,
+	}, nil
+}
+
+func isValidFunction(ctx types.Context, val any) (any, error) {
+	if _, ok := val.(Semver); ok {
+		return true, nil
+	}
+
+	str, err := ctx.Coalesce().ToString(val)
+	if err != nil {
+		return false, nil
+	}
+
+	_, err = ParseStrict(str)
+
+	return err == nil, nil
+}
+
+func bumpFunction(ctx types.Context, version any, kind string) (any, error) {
+	return bumpPrereleaseFunction(ctx, version, kind, "")
+}
+
+func bumpPrereleaseFunction(ctx types.Context, version any, kind string, identifier string) (any, error) {
+	v, err := toSemver(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	next, err := Bump(v.Version, kind, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return Semver{
+		Version: next,
+	}, nil
+}
+
+func isPrereleaseFunction(ctx types.Context, version any) (any, error) {
+	v, err := toSemver(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	return len(v.Version.Pre) > 0, nil
+}
+
+func setPrereleaseFunction(ctx types.Context, version any, identifiers any) (any, error) {
+	v, err := toSemver(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	strs, err := toIdentifiers(ctx, identifiers)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	pres := make([]blangsemver.PRVersion, len(strs))
+	for i, str := range strs {
+		pres[i], err = blangsemver.NewPRVersion(str)
+		if err != nil {
+			return nil, fmt.Errorf("argument #1: %w", err)
+		}
+	}
+
+	result := copyVersion(v.Version)
+	result.Pre = pres
+
+	return Semver{Version: result}, nil
+}
+
+func setBuildFunction(ctx types.Context, version any, identifiers any) (any, error) {
+	v, err := toSemver(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	strs, err := toIdentifiers(ctx, identifiers)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	builds := make([]string, len(strs))
+	for i, str := range strs {
+		builds[i], err = blangsemver.NewBuildVersion(str)
+		if err != nil {
+			return nil, fmt.Errorf("argument #1: %w", err)
+		}
+	}
+
+	result := copyVersion(v.Version)
+	result.Build = builds
+
+	return Semver{Version: result}, nil
+}
+
+func stripPrereleaseFunction(ctx types.Context, version any) (any, error) {
+	return setPrereleaseFunction(ctx, version, []any{})
+}
+
+func stripBuildFunction(ctx types.Context, version any) (any, error) {
+	return setBuildFunction(ctx, version, []any{})
+}
+
+// toIdentifiers turns either a dot-separated string ("rc.1") or a vector of
+// strings and numbers (["rc" 1]) into a list of identifiers.
+func toIdentifiers(ctx types.Context, val any) ([]string, error) {
+	vec, err := ctx.Coalesce().ToVector(val)
+	if err != nil {
+		str, err := ctx.Coalesce().ToString(val)
+		if err != nil {
+			return nil, fmt.Errorf("neither vector nor string, but %T", val)
+		}
+
+		if str == "" {
+			return []string{}, nil
+		}
+
+		return strings.Split(str, "."), nil
+	}
+
+	result := make([]string, len(vec))
+	for i, item := range vec {
+		if n, err := ctx.Coalesce().ToInt64(item); err == nil {
+			result[i] = strconv.FormatInt(n, 10)
+			continue
+		}
+
+		result[i], err = ctx.Coalesce().ToString(item)
+		if err != nil {
+			return nil, fmt.Errorf("vector contains neither string nor number, but %T", item)
+		}
+	}
+
+	return result, nil
+}
+
+func goPseudoFunction(version string) (any, error) {
+	return IsPseudoVersion(version), nil
+}
+
+func goPseudoTimeFunction(version string) (any, error) {
+	timestamp, _, err := ParsePseudoVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return timestamp.Format(time.RFC3339), nil
+}
+
+func goPseudoRevFunction(version string) (any, error) {
+	_, rev, err := ParsePseudoVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+func goCompareFunction(a string, b string) (any, error) {
+	return int64(CompareGoVersions(a, b)), nil
+}
+
+func goIncompatibleFunction(version string) (any, error) {
+	if _, err := ParseGoVersion(version); err != nil {
+		return nil, err
+	}
+
+	return strings.HasSuffix(version, incompatibleSuffix), nil
+}
+
+func goMajorSuffixFunction(version string) (any, error) {
+	return MajorPathSuffix(version)
+}
+
+func diffFunction(ctx types.Context, a any, b any) (any, error) {
+	va, err := toSemver(ctx, a)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	vb, err := toSemver(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	return Difference(va.Version, vb.Version), nil
+}
+
+func isBreakingFunction(ctx types.Context, from any, to any) (any, error) {
+	vFrom, err := toSemver(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	vTo, err := toSemver(ctx, to)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	return IsBreaking(vFrom.Version, vTo.Version), nil
+}
+
+func parseRangeFunction(constraint string) (any, error) {
+	return ParseRange(constraint)
+}
+
+func parseRangeDialectFunction(constraint string, dialect string) (any, error) {
+	return ParseRangeDialect(constraint, Dialect(dialect))
+}
+
+func formatRangeFunction(ctx types.Context, constraint any, dialect string) (any, error) {
+	r, err := toRange(ctx, constraint)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	return FormatRange(r, Dialect(dialect))
+}
+
+func rangeEmptyFunction(ctx types.Context, constraint any) (any, error) {
+	r, err := toRange(ctx, constraint)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	return r.IsEmpty(), nil
+}
+
+func rangeComplementFunction(ctx types.Context, constraint any) (any, error) {
+	r, err := toRange(ctx, constraint)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	return r.Complement(), nil
+}
+
+func rangeIntersectFunction(ctx types.Context, a any, b any) (any, error) {
+	return combineRanges(ctx, a, b, Range.Intersect)
+}
+
+func rangeUnionFunction(ctx types.Context, a any, b any) (any, error) {
+	return combineRanges(ctx, a, b, Range.Union)
+}
+
+func combineRanges(ctx types.Context, a any, b any, combine func(Range, Range) Range) (any, error) {
+	ra, err := toRange(ctx, a)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	rb, err := toRange(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	return combine(ra, rb), nil
+}
+
+func satisfiesFunction(ctx types.Context, version any, constraint any) (any, error) {
+	v, err := toSemver(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	r, err := toRange(ctx, constraint)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	return r.Satisfies(v.Version), nil
+}
+
+func skewViolationsFunction(ctx types.Context, controlPlane any, components map[string]any) (any, error) {
+	return skewViolationsWithPolicyFunction(ctx, controlPlane, components, map[string]any{})
+}
+
+func skewViolationsWithPolicyFunction(ctx types.Context, controlPlane any, components map[string]any, policy map[string]any) (any, error) {
+	violations, err := checkSkew(ctx, controlPlane, components, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]any, len(violations))
+	for i, violation := range violations {
+		result[i] = violation
+	}
+
+	return result, nil
+}
+
+func skewOKFunction(ctx types.Context, controlPlane any, components map[string]any) (any, error) {
+	return skewOKWithPolicyFunction(ctx, controlPlane, components, map[string]any{})
+}
+
+func skewOKWithPolicyFunction(ctx types.Context, controlPlane any, components map[string]any, policy map[string]any) (any, error) {
+	violations, err := checkSkew(ctx, controlPlane, components, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return len(violations) == 0, nil
+}
+
+func checkSkew(ctx types.Context, controlPlane any, components map[string]any, policy map[string]any) ([]string, error) {
+	cp, err := toSemver(ctx, controlPlane)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	versions := map[string]blangsemver.Version{}
+	for name, version := range components {
+		v, err := toSemver(ctx, version)
+		if err != nil {
+			return nil, fmt.Errorf("argument #1: %s: %w", name, err)
+		}
+
+		versions[name] = v.Version
+	}
+
+	rules, err := toSkewPolicy(ctx, policy)
+	if err != nil {
+		return nil, fmt.Errorf("argument #2: %w", err)
+	}
+
+	return CheckSkew(cp.Version, versions, rules)
+}
+
+// toSkewPolicy merges the given policy into the default policy. Each rule can
+// either be a number (the allowed number of older minor versions) or an object
+// with "older" and "newer" keys.
+func toSkewPolicy(ctx types.Context, policy map[string]any) (SkewPolicy, error) {
+	result := SkewPolicy{}
+	for name, rule := range DefaultSkewPolicy {
+		result[name] = rule
+	}
+
+	for name, value := range policy {
+		if older, err := ctx.Coalesce().ToInt64(value); err == nil {
+			result[name] = SkewRule{Older: older}
+			continue
+		}
+
+		obj, err := ctx.Coalesce().ToObject(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: rule must be a number or an object, but is %T", name, value)
+		}
+
+		rule := SkewRule{}
+		for key, val := range obj {
+			n, err := ctx.Coalesce().ToInt64(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", name, key, err)
+			}
+
+			switch key {
+			case "older":
+				rule.Older = n
+			case "newer":
+				rule.Newer = n
+			default:
+				return nil, fmt.Errorf("%s: unknown key %q", name, key)
+			}
+		}
+
+		result[name] = rule
+	}
+
+	return result, nil
+}
+
+func minorStepsFunction(ctx types.Context, from any, to any) (any, error) {
+	vFrom, err := toSemver(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	vTo, err := toSemver(ctx, to)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	steps, err := MinorSteps(vFrom.Version, vTo.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]any, len(steps))
+	for i, step := range steps {
+		result[i] = step
+	}
+
+	return result, nil
+}
+
+func newEmptyVersionMapFunction() (any, error) {
+	return NewVersionMap(), nil
+}
+
+func newVersionMapFunction(ctx types.Context, obj map[string]any) (any, error) {
+	// iterate in a stable order, so that conflicts are reported deterministically
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	m := NewVersionMap()
+	originalKeys := NewVersionMap()
+
+	for _, key := range keys {
+		v, err := blangsemver.ParseTolerant(key)
+		if err != nil {
+			return nil, fmt.Errorf("argument #0: key %q: %w", key, err)
+		}
+
+		if other, exists := originalKeys.Get(v); exists {
+			return nil, fmt.Errorf("argument #0: keys %q and %q refer to the same version %s", other, key, v)
+		}
+
+		m = m.Set(v, obj[key])
+		originalKeys = originalKeys.Set(v, key)
+	}
+
+	return m, nil
+}
+
+func toVersionMap(val any) (VersionMap, error) {
+	m, ok := val.(VersionMap)
+	if !ok {
+		return VersionMap{}, fmt.Errorf("not a version map, but %T", val)
+	}
+
+	return m, nil
+}
+
+func versionMapSetFunction(ctx types.Context, target any, version any, value any) (any, error) {
+	m, err := toVersionMap(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	v, err := toSemver(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	return m.Set(v.Version, value), nil
+}
+
+func versionMapGetFunction(ctx types.Context, target any, version any) (any, error) {
+	return versionMapGetDefaultFunction(ctx, target, version, nil)
+}
+
+func versionMapGetDefaultFunction(ctx types.Context, target any, version any, fallback any) (any, error) {
+	m, err := toVersionMap(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	v, err := toSemver(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	value, ok := m.Get(v.Version)
+	if !ok {
+		return fallback, nil
+	}
+
+	return value, nil
+}
+
+func versionMapDeleteFunction(ctx types.Context, target any, versions ...any) (any, error) {
+	m, err := toVersionMap(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	parsed := make([]blangsemver.Version, len(versions))
+	for i, version := range versions {
+		v, err := toSemver(ctx, version)
+		if err != nil {
+			return nil, fmt.Errorf("argument #%d: %w", i+1, err)
+		}
+
+		parsed[i] = v.Version
+	}
+
+	return m.Delete(parsed...), nil
+}
+
+func versionMapSizeFunction(target any) (any, error) {
+	m, err := toVersionMap(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	return int64(m.Len()), nil
+}
+
+func versionMapKeysFunction(target any) (any, error) {
+	m, err := toVersionMap(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	result := []any{}
+	for _, entry := range m.Entries() {
+		result = append(result, Semver{Version: entry.Version})
+	}
+
+	return result, nil
+}
+
+func versionMapValuesFunction(target any) (any, error) {
+	m, err := toVersionMap(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	result := []any{}
+	for _, entry := range m.Entries() {
+		result = append(result, entry.Value)
+	}
+
+	return result, nil
+}
+
+func versionMapEntriesFunction(target any) (any, error) {
+	m, err := toVersionMap(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	result := []any{}
+	for _, entry := range m.Entries() {
+		result = append(result, []any{Semver{Version: entry.Version}, entry.Value})
+	}
+
+	return result, nil
+}
+
+func sortFunction(ctx types.Context, list []any) (any, error) {
+	return sortStableFunction(ctx, list, false)
+}
+
+func sortStableFunction(ctx types.Context, list []any, stableOnly bool) (any, error) {
+	versions := parseVersionList(ctx, list, stableOnly)
+
+	result := make([]any, len(versions))
+	for i, v := range versions {
+		result[i] = v.original
+	}
+
+	return result, nil
+}
+
+func maxFunction(ctx types.Context, list []any) (any, error) {
+	return maxStableFunction(ctx, list, false)
+}
+
+func maxStableFunction(ctx types.Context, list []any, stableOnly bool) (any, error) {
+	versions := parseVersionList(ctx, list, stableOnly)
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	return versions[len(versions)-1].original, nil
+}
+
+func minFunction(ctx types.Context, list []any) (any, error) {
+	return minStableFunction(ctx, list, false)
+}
+
+func minStableFunction(ctx types.Context, list []any, stableOnly bool) (any, error) {
+	versions := parseVersionList(ctx, list, stableOnly)
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	return versions[0].original, nil
+}
+
+func latestFunction(ctx types.Context, list []any) (any, error) {
+	return maxStableFunction(ctx, list, true)
+}
+
+func latestInRangeFunction(ctx types.Context, list []any, constraint any) (any, error) {
+	return latestInRangeWithPrereleasesFunction(ctx, list, constraint, false)
+}
+
+func latestInRangeWithPrereleasesFunction(ctx types.Context, list []any, constraint any, includePrereleases bool) (any, error) {
+	r, err := toRange(ctx, constraint)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	versions := parseVersionList(ctx, list, !includePrereleases)
+	for i := len(versions) - 1; i >= 0; i-- {
+		if r.Satisfies(versions[i].version.Version) {
+			return versions[i].original, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func invalidFunction(ctx types.Context, list []any) (any, error) {
+	result := []any{}
+	for _, item := range list {
+		if _, err := toSemver(ctx, item); err != nil {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+type listedVersion struct {
+	original any
+	version  Semver
+}
+
+// parseVersionList parses all items in the list and returns them sorted in
+// ascending order. Items that are not valid versions are silently skipped
+// (use semver-invalid to find them).
+func parseVersionList(ctx types.Context, list []any, stableOnly bool) []listedVersion {
+	result := []listedVersion{}
+
+	for _, item := range list {
+		v, err := toSemver(ctx, item)
+		if err != nil {
+			continue
+		}
+
+		if stableOnly && len(v.Version.Pre) > 0 {
+			continue
+		}
+
+		result = append(result, listedVersion{
+			original: item,
+			version:  v,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].version.Version.LT(result[j].version.Version)
+	})
+
+	return result
+}
+
+// toSemver returns Semver values as-is and parses everything else that can be
+// coalesced into a string.
+func toSemver(ctx types.Context, val any) (Semver, error) {
+	if v, ok := val.(Semver); ok {
+		return v, nil
+	}
+
+	str, err := ctx.Coalesce().ToString(val)
+	if err != nil {
+		return Semver{}, fmt.Errorf("not a semver, but %T", val)
+	}
+
+	parsed, err := parseFunction(str)
+	if err != nil {
+		return Semver{}, err
+	}
+
+	return parsed.(Semver), nil
+}
+
+// toRange returns Range values as-is and parses everything else that can be
+// coalesced into a string.
+func toRange(ctx types.Context, val any) (Range, error) {
+	if r, ok := val.(Range); ok {
+		return r, nil
+	}
+
+	str, err := ctx.Coalesce().ToString(val)
+	if err != nil {
+		return Range{}, fmt.Errorf("not a range, but %T", val)
+	}
+
+	return ParseRange(str)
+}
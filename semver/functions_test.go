@@ -125,3 +125,833 @@ func TestParseFunction(t *testing.T) {
 		t.Run(testcase.String(), testcase.Run)
 	}
 }
+
+func TestParseRangeFunction(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver-range "foo")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-range ">=1.2.0 <2.0.0")`,
+			Expected: Range{
+				Sets: [][]Comparator{
+					{
+						{Operator: OpGreaterEqual, Version: blangsemver.MustParse("1.2.0")},
+						{Operator: OpLess, Version: blangsemver.MustParse("2.0.0")},
+					},
+				},
+			},
+		},
+		{
+			Expression: `(to-string (semver-range ">=1.2.0 <2.0.0 || ^3.1"))`,
+			Expected:   ">=1.2.0 <2.0.0 || >=3.1.0 <4.0.0-0",
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestSatisfiesFunction(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver-satisfies? "foo" ">=1.2")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-satisfies? "1.2.3" "foo")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-satisfies? (semver "1.2.3") (semver-range ">=1.2 <2"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-satisfies? "v1.2" ">=1.2 <2")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-satisfies? "2.0.0" ">=1.2 <2")`,
+			Expected:   false,
+		},
+		{
+			Expression: `(semver-satisfies? "2.0.0-rc.1" ">=1.2 <2")`,
+			Expected:   false,
+		},
+		{
+			Expression: `(semver-satisfies? "3.4.0" ">=1.2.0 <2.0.0 || ^3.1")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-satisfies? "4.0.0" ">=1.2.0 <2.0.0 || ^3.1")`,
+			Expected:   false,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestBumpFunction(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver-bump "foo" "major")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-bump "1.2.3" "foo")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-bump (semver "1.2.3") "minor")`,
+			Expected: Semver{
+				Version: blangsemver.MustParse("1.3.0"),
+			},
+		},
+		{
+			Expression: `(to-string (semver-bump "v1.2" "patch"))`,
+			Expected:   "1.2.1",
+		},
+		{
+			Expression: `(to-string (semver-bump "1.2.3-rc.1" "prerelease"))`,
+			Expected:   "1.2.3-rc.2",
+		},
+		{
+			Expression: `(to-string (semver-bump "1.2.3" "prerelease" "rc"))`,
+			Expected:   "1.2.4-rc.0",
+		},
+		{
+			Expression: `(to-string (semver-bump "1.2.3-rc.2" "release"))`,
+			Expected:   "1.2.3",
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestPrereleaseAndBuildFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver "1.2.3-rc.1+abc").pre`,
+			Expected:   []any{"rc", int64(1)},
+		},
+		{
+			Expression: `(semver "1.2.3-rc.1+abc").build`,
+			Expected:   []any{"abc"},
+		},
+		{
+			Expression: `(semver "1.2.3").pre`,
+			Expected:   []any{},
+		},
+		{
+			Expression: `(semver-prerelease? "1.2.3-rc.1")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-prerelease? (semver "1.2.3+abc"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(to-string (semver-set-pre "1.2.3" ["rc" 2]))`,
+			Expected:   "1.2.3-rc.2",
+		},
+		{
+			Expression: `(to-string (semver-set-pre "1.2.3-alpha+abc" "rc.2"))`,
+			Expected:   "1.2.3-rc.2+abc",
+		},
+		{
+			Expression: `(semver-set-pre "1.2.3" "rc.01")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(to-string (semver-set-build "1.2.3-rc.1" ["abc" "def"]))`,
+			Expected:   "1.2.3-rc.1+abc.def",
+		},
+		{
+			Expression: `(semver-set-build "1.2.3" "a_b")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(to-string (semver-strip-pre "1.2.3-rc.1+abc"))`,
+			Expected:   "1.2.3+abc",
+		},
+		{
+			Expression: `(to-string (semver-strip-build "1.2.3-rc.1+abc"))`,
+			Expected:   "1.2.3-rc.1",
+		},
+		{
+			// do not modify in-place
+			Expression: `(set! $v (semver "1.2.3-rc.1")) (semver-strip-pre $v) (to-string $v)`,
+			Expected:   "1.2.3-rc.1",
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestSortFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver-sort "nope")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-sort [])`,
+			Expected:   []any{},
+		},
+		{
+			Expression: `(semver-sort ["v1.10.0" "foo" "v1.9.0" "1.9.1-rc.1" "v1.2"])`,
+			Expected:   []any{"v1.2", "v1.9.0", "1.9.1-rc.1", "v1.10.0"},
+		},
+		{
+			Expression: `(semver-sort ["v1.10.0" "foo" "v1.9.0" "1.9.1-rc.1" "v1.2"] true)`,
+			Expected:   []any{"v1.2", "v1.9.0", "v1.10.0"},
+		},
+		{
+			Expression: `(semver-sort ["1.2.0" (semver "1.1")])`,
+			Expected: []any{
+				Semver{Version: blangsemver.MustParse("1.1.0")},
+				"1.2.0",
+			},
+		},
+		{
+			Expression: `(semver-max [])`,
+			Expected:   nil,
+		},
+		{
+			Expression: `(semver-max ["v1.10.0" "foo" "v1.9.0" "v2.0.0-beta.1"])`,
+			Expected:   "v2.0.0-beta.1",
+		},
+		{
+			Expression: `(semver-max ["v1.10.0" "foo" "v1.9.0" "v2.0.0-beta.1"] true)`,
+			Expected:   "v1.10.0",
+		},
+		{
+			Expression: `(semver-min ["v1.10.0" "foo" "v1.9.0" "v1.0.0-beta.1"])`,
+			Expected:   "v1.0.0-beta.1",
+		},
+		{
+			Expression: `(semver-min ["v1.10.0" "foo" "v1.9.0" "v1.0.0-beta.1"] true)`,
+			Expected:   "v1.9.0",
+		},
+		{
+			Expression: `(semver-latest ["v1.10.0" "foo" "v1.9.0" "v2.0.0-beta.1"])`,
+			Expected:   "v1.10.0",
+		},
+		{
+			Expression: `(semver-latest ["v1.10.0" "foo" "v1.9.0" "v2.0.0-beta.1"] "<1.10")`,
+			Expected:   "v1.9.0",
+		},
+		{
+			Expression: `(semver-latest ["v1.10.0" "foo" "v1.9.0" "v2.0.0-beta.1"] "^2.0.0-0")`,
+			Expected:   nil,
+		},
+		{
+			Expression: `(semver-latest ["v1.10.0" "foo" "v1.9.0" "v2.0.0-beta.1"] "^2.0.0-0" true)`,
+			Expected:   "v2.0.0-beta.1",
+		},
+		{
+			Expression: `(semver-latest ["v1.10.0"] "foo")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-invalid ["v1.10.0" "foo" "v1.9.0" ""])`,
+			Expected:   []any{"foo", ""},
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestStrictFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver-strict "v1.2.3")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-strict "1.2")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-strict "01.2.3")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-strict "1.2.3-beta.3")`,
+			Expected: Semver{
+				Version: blangsemver.MustParse("1.2.3-beta.3"),
+			},
+		},
+		{
+			Expression: `(semver-valid? "1.2.3")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-valid? "v1.2.3")`,
+			Expected:   false,
+		},
+		{
+			Expression: `(semver-valid? "1.2.03")`,
+			Expected:   false,
+		},
+		{
+			Expression: `(semver-valid? (semver "v1.2"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-valid? {foo "bar"})`,
+			Expected:   false,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestGoModuleFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver-go-pseudo? "v0.0.0-20231010123456-abcdef123456")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-go-pseudo? "v2.0.0+incompatible")`,
+			Expected:   false,
+		},
+		{
+			Expression: `(semver-go-pseudo-time "v1.2.4-0.20231010123456-abcdef123456")`,
+			Expected:   "2023-10-10T12:34:56Z",
+		},
+		{
+			Expression: `(semver-go-pseudo-rev "v1.2.4-0.20231010123456-abcdef123456")`,
+			Expected:   "abcdef123456",
+		},
+		{
+			Expression: `(semver-go-pseudo-rev "v1.2.4")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-go-compare "v1.2.4-0.20231010123456-abcdef123456" "v1.2.4")`,
+			Expected:   int64(-1),
+		},
+		{
+			Expression: `(semver-go-compare "v2.0.0+incompatible" "v2")`,
+			Expected:   int64(0),
+		},
+		{
+			Expression: `(semver-go-compare "v1.0.0" "foo")`,
+			Expected:   int64(1),
+		},
+		{
+			Expression: `(semver-go-incompatible? "v2.0.0+incompatible")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-go-incompatible? "2.0.0+incompatible")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-go-major-suffix "v2.1.0")`,
+			Expected:   "/v2",
+		},
+		{
+			Expression: `(semver-go-major-suffix "v1.1.0")`,
+			Expected:   "",
+		},
+		{
+			Expression: `(semver-go-major-suffix "v2.1.0+incompatible")`,
+			Expected:   "",
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestRangeDialectFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver-range "1.2" "foo")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(to-string (semver-range "1.2 - 1.4" "npm"))`,
+			Expected:   ">=1.2.0 <1.5.0-0",
+		},
+		{
+			Expression: `(to-string (semver-range "1.2, <1.5" "cargo"))`,
+			Expected:   ">=1.2.0 <2.0.0-0 <1.5.0-0",
+		},
+		{
+			Expression: `(semver-satisfies? "1.9.0" (semver-range "1.2" "cargo"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-range-string (semver-range "^1.2") "cargo")`,
+			Expected:   ">=1.2.0, <2.0.0-0",
+		},
+		{
+			Expression: `(semver-range-string "~1.2" "npm")`,
+			Expected:   ">=1.2.0 <1.3.0-0",
+		},
+		{
+			Expression: `(semver-range-string "1 || 2" "cargo")`,
+			Invalid:    true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestDiffFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver-diff "foo" "1.2.3")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-diff (semver "1.2.3") "v1.3")`,
+			Expected:   "minor",
+		},
+		{
+			Expression: `(semver-diff "1.2.3-rc.1" "1.2.3")`,
+			Expected:   "prerelease",
+		},
+		{
+			Expression: `(semver-diff "1.2.3" "v1.2.3")`,
+			Expected:   "none",
+		},
+		{
+			Expression: `(semver-breaking? "1.2.3" "1.9.0")`,
+			Expected:   false,
+		},
+		{
+			Expression: `(semver-breaking? "1.2.3" "2.0.0")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-breaking? "0.2.3" "0.3.0")`,
+			Expected:   true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestCalverFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(calver "2024.10.1")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(calver "2024.10.1" "YY.0M")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(calver "2024.10.1" "YYYY.MM.MICRO")`,
+			Expected: Calver{
+				Format: "YYYY.MM.MICRO",
+				Year:   2024,
+				Month:  10,
+				Micro:  1,
+			},
+		},
+		{
+			Expression: `(to-string (calver "2023.12.0-beta" "YYYY.0M.MICRO"))`,
+			Expected:   "2023.12.0-beta",
+		},
+		{
+			Expression: `(gt? (calver "2023.12.0" "YYYY.0M.MICRO") (calver "2023.12.0-beta" "YYYY.0M.MICRO"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(eq? (calver "24.04" "YY.0M") (calver "2024.4" "YYYY.MM"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(calver-date (calver "24.04" "YY.0M"))`,
+			Expected:   "2024-04-01",
+		},
+		{
+			Expression: `(calver-date "24.04")`,
+			Invalid:    true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestPEP440Function(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(pep440 "foo")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(pep440 "1.0.post1")`,
+			Expected: PEP440{
+				Release: []int64{1, 0},
+				HasPost: true,
+				Post:    1,
+			},
+		},
+		{
+			Expression: `(to-string (pep440 "2.0-RC.1"))`,
+			Expected:   "2.0rc1",
+		},
+		{
+			Expression: `(eq? (pep440 "1.0") (pep440 "1.0.0"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(gt? (pep440 "1.0.post1") (pep440 "1.0"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(gt? (pep440 "2.0rc1") (pep440 "2.0"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(eq? (pep440 "1.0") "1.0")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(eq? (pep440 "1.0") "1.0")`,
+			Expected:   true,
+			Coalescer:  coalescing.NewHumane(),
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestDebianVersionFunction(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(debian-version "foo")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(debian-version "1:2.3-4ubuntu1")`,
+			Expected: DebianVersion{
+				Epoch:    1,
+				Upstream: "2.3",
+				Revision: "4ubuntu1",
+			},
+		},
+		{
+			Expression: `(to-string (debian-version "1:2.3-4ubuntu1"))`,
+			Expected:   "1:2.3-4ubuntu1",
+		},
+		{
+			Expression: `(gt? (debian-version "1:2.3-4ubuntu1") (debian-version "2.4-1"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(gt? (debian-version "1.0~rc1") (debian-version "1.0"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(eq? (debian-version "1.0") (debian-version "1.0-0"))`,
+			Expected:   true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestCompareWithStrings(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(eq? (semver-comparable "v9.2") "9.2.0")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(gt? (semver-comparable "v9.2") "9.1")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(lt? (semver-comparable "v9.2") "v10")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(gt? (semver-comparable "v9.2") "foo")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(eq? (semver-comparable "v9.2") 9)`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-comparable "foo")`,
+			Invalid:    true,
+		},
+		{
+			// plain semvers are not affected
+			Expression: `(gt? (semver "v9.2") "9.1")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(eq? (semver-comparable "v9.2") (semver "9.2.0"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(gt? "1.2" $v)`,
+			Variables:  map[string]any{"v": Semver{Version: blangsemver.MustParse("1.1.0"), CompareWithStrings: true}},
+			Expected:   true,
+		},
+		{
+			Expression: `(eq? "9.2.0" $v)`,
+			Variables:  map[string]any{"v": Semver{Version: blangsemver.MustParse("9.2.0"), CompareWithStrings: true}},
+			Expected:   true,
+		},
+		{
+			Expression: `(lt? "9.2.0" $v)`,
+			Variables:  map[string]any{"v": Semver{Version: blangsemver.MustParse("9.2.0"), CompareWithStrings: true}},
+			Expected:   false,
+		},
+		{
+			Expression: `(eq? "9.2.0" $v)`,
+			Variables:  map[string]any{"v": Semver{Version: blangsemver.MustParse("9.2.0")}},
+			Invalid:    true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestSkewFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver-skew-ok? "1.28.3" {kubelet "1.25.0" kubectl "1.29.1"})`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-skew-ok? "1.28.3" {kubelet "1.24.0"})`,
+			Expected:   false,
+		},
+		{
+			Expression: `(semver-skew-ok? "1.28.3" {kubelet "1.24.0"} {kubelet 4})`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-skew-ok? "1.28.3" {kubectl "1.30.0"} {kubectl {older 1 newer 2}})`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-skew-ok? "1.28.3" {foo "1.28.0"})`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-skew-ok? "1.28.3" {kubelet "1.28.0"} {kubelet {foo 1}})`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-skew-violations "1.28.3" {kubelet "1.28.0"})`,
+			Expected:   []any{},
+		},
+		{
+			Expression: `(semver-skew-violations "1.28.3" {kubelet "1.24.0" kube-proxy "1.29.0"})`,
+			Expected: []any{
+				"kube-proxy 1.29.0 is 1 minor versions newer than the control plane 1.28.3 (at most 0 allowed)",
+				"kubelet 1.24.0 is 4 minor versions older than the control plane 1.28.3 (at most 3 allowed)",
+			},
+		},
+		{
+			Expression: `(semver-minor-steps "1.25.3" (semver "v1.28"))`,
+			Expected:   []any{"1.26", "1.27", "1.28"},
+		},
+		{
+			Expression: `(semver-minor-steps "1.25.3" "1.25.9")`,
+			Expected:   []any{},
+		},
+		{
+			Expression: `(semver-minor-steps "1.25.3" "1.24.0")`,
+			Invalid:    true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestRangeAlgebraFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(semver-range-empty? "foo")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-range-empty? ">=1.2 <2")`,
+			Expected:   false,
+		},
+		{
+			Expression: `(semver-range-empty? (semver-range ">=2 <1.2"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(semver-range-empty? (semver-range-intersect ">=1.2 <2" "^1.5 || ^3"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(semver-range-empty? (semver-range-intersect ">=1.2 <2" "^3"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(to-string (semver-range-intersect ">=1.2 <2" "^1.5 || ^3"))`,
+			Expected:   ">=1.5.0 <2.0.0-0",
+		},
+		{
+			Expression: `(to-string (semver-range-union "^1.2" (semver-range "^1.5 || ^3")))`,
+			Expected:   ">=1.2.0 <2.0.0-0 || >=3.0.0 <4.0.0-0",
+		},
+		{
+			Expression: `(to-string (semver-range-complement "^1.2"))`,
+			Expected:   "<1.2.0 || >=2.0.0-0",
+		},
+		{
+			Expression: `(semver-satisfies? "1.0.0" (semver-range-complement "^1.2"))`,
+			Expected:   true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestVersionMapFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(new-semver-map {foo 1})`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(new-semver-map {"1.0" 1 "v1.0.0" 2})`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(new-semver-map {"1.0.0+a" 1 "1.0.0+b" 2})`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(semver-map-size (new-semver-map))`,
+			Expected:   int64(0),
+		},
+		{
+			Expression: `(semver-map-values (new-semver-map {"1.10" "b" "1.9" "a" "v2" "c"}))`,
+			Expected:   []any{"a", "b", "c"},
+		},
+		{
+			Expression: `(semver-map-keys (semver-map-set (new-semver-map {"1.10" "b"}) "1.9" "a"))`,
+			Expected: []any{
+				Semver{Version: blangsemver.MustParse("1.9.0")},
+				Semver{Version: blangsemver.MustParse("1.10.0")},
+			},
+		},
+		{
+			Expression: `(semver-map-entries (new-semver-map {"1.10" "b"}))`,
+			Expected: []any{
+				[]any{Semver{Version: blangsemver.MustParse("1.10.0")}, "b"},
+			},
+		},
+		{
+			Expression: `(semver-map-get (new-semver-map {"1.10" "b"}) (semver "v1.10.0"))`,
+			Expected:   "b",
+		},
+		{
+			Expression: `(semver-map-get (new-semver-map {"1.10" "b"}) "1.9")`,
+			Expected:   nil,
+		},
+		{
+			Expression: `(semver-map-get (new-semver-map {"1.10" "b"}) "1.9" "default")`,
+			Expected:   "default",
+		},
+		{
+			Expression: `(semver-map-values (semver-map-delete (new-semver-map {"1.10" "b" "1.9" "a" "v2" "c"}) "1.9" "2.0.0"))`,
+			Expected:   []any{"b"},
+		},
+		{
+			Expression: `(semver-map-size {"1.10" "b"})`,
+			Invalid:    true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
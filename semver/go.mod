@@ -5,6 +5,7 @@ go 1.18
 require (
 	github.com/blang/semver/v4 v4.0.0
 	go.xrstf.de/rudi v0.5.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require github.com/google/go-cmp v0.6.0 // indirect
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+var (
+	// pseudoVersionRegex is taken from golang.org/x/mod/module.
+	pseudoVersionRegex = regexp.MustCompile(`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+)
+
+const (
+	pseudoVersionTimeFormat = "20060102150405"
+	incompatibleSuffix      = "+incompatible"
+)
+
+// ParseGoVersion parses a version the way the Go toolchain does: The leading
+// "v" is required and the shorthands "vMAJOR" and "vMAJOR.MINOR" are allowed
+// (but not in combination with prerelease or build suffixes). Other than that
+// the version has to be strictly valid.
+func ParseGoVersion(s string) (blangsemver.Version, error) {
+	if !strings.HasPrefix(s, "v") {
+		return blangsemver.Version{}, fmt.Errorf("invalid Go version %q: must start with \"v\"", s)
+	}
+
+	version := s[1:]
+	core := version
+	if idx := strings.IndexAny(version, "-+"); idx != -1 {
+		core = version[:idx]
+	}
+
+	if components := strings.Count(core, "."); components < 2 {
+		if core != version {
+			return blangsemver.Version{}, fmt.Errorf("invalid Go version %q: shorthand versions cannot have prerelease or build suffixes", s)
+		}
+
+		version += strings.Repeat(".0", 2-components)
+	}
+
+	return ParseStrict(version)
+}
+
+// IsPseudoVersion returns true if the given string is a Go module
+// pseudo-version like "v0.0.0-20231010123456-abcdef123456".
+func IsPseudoVersion(s string) bool {
+	if !pseudoVersionRegex.MatchString(s) {
+		return false
+	}
+
+	_, err := ParseGoVersion(s)
+
+	return err == nil
+}
+
+// ParsePseudoVersion returns the commit timestamp and revision identifier
+// encoded in a Go module pseudo-version.
+func ParsePseudoVersion(s string) (time.Time, string, error) {
+	if !IsPseudoVersion(s) {
+		return time.Time{}, "", fmt.Errorf("%q is not a pseudo-version", s)
+	}
+
+	// strip build metadata like "+incompatible"
+	if idx := strings.IndexByte(s, '+'); idx != -1 {
+		s = s[:idx]
+	}
+
+	revIdx := strings.LastIndexByte(s, '-')
+	rev := s[revIdx+1:]
+	s = s[:revIdx]
+
+	timestampIdx := strings.LastIndexAny(s, "-.")
+	timestamp, err := time.Parse(pseudoVersionTimeFormat, s[timestampIdx+1:])
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%q is not a pseudo-version: %w", s, err)
+	}
+
+	return timestamp, rev, nil
+}
+
+// CompareGoVersions compares two versions like golang.org/x/mod/semver does:
+// Invalid versions are considered smaller than all valid versions and equal
+// to each other, build metadata is ignored.
+func CompareGoVersions(a, b string) int {
+	va, errA := ParseGoVersion(a)
+	vb, errB := ParseGoVersion(b)
+
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	default:
+		return va.Compare(vb)
+	}
+}
+
+// MajorPathSuffix returns the suffix that a Go module path needs to have for
+// the given version, e.g. "/v2" for "v2.1.0". Versions before v2 and versions
+// marked with "+incompatible" do not need a suffix.
+func MajorPathSuffix(s string) (string, error) {
+	v, err := ParseGoVersion(s)
+	if err != nil {
+		return "", err
+	}
+
+	if v.Major < 2 || strings.HasSuffix(s, incompatibleSuffix) {
+		return "", nil
+	}
+
+	return fmt.Sprintf("/v%d", v.Major), nil
+}
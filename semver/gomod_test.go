@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePseudoVersion(t *testing.T) {
+	testcases := []struct {
+		version string
+		pseudo  bool
+		time    string
+		rev     string
+	}{
+		{version: "v0.0.0-20231010123456-abcdef123456", pseudo: true, time: "2023-10-10T12:34:56Z", rev: "abcdef123456"},
+		{version: "v1.2.4-0.20231010123456-abcdef123456", pseudo: true, time: "2023-10-10T12:34:56Z", rev: "abcdef123456"},
+		{version: "v1.2.3-pre.0.20231010123456-abcdef123456", pseudo: true, time: "2023-10-10T12:34:56Z", rev: "abcdef123456"},
+		{version: "v2.0.0-20231010123456-abcdef123456+incompatible", pseudo: true, time: "2023-10-10T12:34:56Z", rev: "abcdef123456"},
+		{version: "v1.2.3"},
+		{version: "v2.0.0+incompatible"},
+		{version: "v0.0.0-2023101012345-abcdef123456"},
+		{version: "0.0.0-20231010123456-abcdef123456"},
+		{version: "v0.0.0-20231310123456-abcdef123456", pseudo: true}, // invalid month
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.version, func(t *testing.T) {
+			if IsPseudoVersion(testcase.version) != testcase.pseudo {
+				t.Fatalf("Expected IsPseudoVersion to return %v", testcase.pseudo)
+			}
+
+			timestamp, rev, err := ParsePseudoVersion(testcase.version)
+			if err != nil {
+				if testcase.rev != "" {
+					t.Fatalf("Failed to parse pseudo-version: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.rev == "" {
+				t.Fatalf("Expected error, but got %v / %s", timestamp, rev)
+			}
+
+			if s := timestamp.Format(time.RFC3339); s != testcase.time {
+				t.Errorf("Expected time %s, but got %s", testcase.time, s)
+			}
+
+			if rev != testcase.rev {
+				t.Errorf("Expected revision %s, but got %s", testcase.rev, rev)
+			}
+		})
+	}
+}
+
+func TestCompareGoVersions(t *testing.T) {
+	testcases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "v1.2.3", b: "v1.2.3", expected: 0},
+		{a: "v1.2", b: "v1.2.0", expected: 0},
+		{a: "v1", b: "v1.0.1", expected: -1},
+		{a: "v2.0.0+incompatible", b: "v2.0.0", expected: 0},
+		{a: "v1.2.4-0.20231010123456-abcdef123456", b: "v1.2.3", expected: 1},
+		{a: "v1.2.4-0.20231010123456-abcdef123456", b: "v1.2.4", expected: -1},
+		{a: "v0.0.0-20231010123456-abcdef123456", b: "v0.0.0-20221010123456-abcdef123456", expected: 1},
+		{a: "1.2.3", b: "v0.0.1", expected: -1},
+		{a: "v1.2.3", b: "foo", expected: 1},
+		{a: "foo", b: "bar", expected: 0},
+		{a: "v01.2.3", b: "v0.0.1", expected: -1},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.a+" "+testcase.b, func(t *testing.T) {
+			if result := CompareGoVersions(testcase.a, testcase.b); result != testcase.expected {
+				t.Fatalf("Expected %d, but got %d", testcase.expected, result)
+			}
+		})
+	}
+}
+
+func TestMajorPathSuffix(t *testing.T) {
+	testcases := []struct {
+		version  string
+		expected string
+		invalid  bool
+	}{
+		{version: "v0.1.0", expected: ""},
+		{version: "v1.9.0", expected: ""},
+		{version: "v2.0.0", expected: "/v2"},
+		{version: "v3.1.0-rc.1", expected: "/v3"},
+		{version: "v2.0.0+incompatible", expected: ""},
+		{version: "2.0.0", invalid: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.version, func(t *testing.T) {
+			suffix, err := MajorPathSuffix(testcase.version)
+			if err != nil {
+				if !testcase.invalid {
+					t.Fatalf("Failed to determine suffix: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.invalid {
+				t.Fatalf("Expected error, but got %q", suffix)
+			}
+
+			if suffix != testcase.expected {
+				t.Fatalf("Expected %q, but got %q", testcase.expected, suffix)
+			}
+		})
+	}
+}
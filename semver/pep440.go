@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.xrstf.de/rudi/pkg/coalescing"
+	"go.xrstf.de/rudi/pkg/deepcopy"
+	"go.xrstf.de/rudi/pkg/equality"
+)
+
+// PEP440 is a Python package version as specified in PEP 440, like
+// "1.0.post1" or "2.0rc1".
+type PEP440 struct {
+	Epoch   int64
+	Release []int64
+	// PreLabel is one of "a", "b", "rc" or empty if this is not a prerelease.
+	PreLabel  string
+	PreNumber int64
+	HasPost   bool
+	Post      int64
+	HasDev    bool
+	Dev       int64
+	Local     []string
+}
+
+var (
+	_ deepcopy.Copier                  = PEP440{}
+	_ coalescing.CustomStringCoalescer = PEP440{}
+	_ equality.Comparer                = PEP440{}
+)
+
+// pep440Regex is taken from the appendix of PEP 440.
+var pep440Regex = regexp.MustCompile(`^v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?P<pre>[-_\.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_\.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?P<post>(?:-(?P<post_n1>[0-9]+))|(?:[-_\.]?(?P<post_l>post|rev|r)[-_\.]?(?P<post_n2>[0-9]+)?))?` +
+	`(?P<dev>[-_\.]?(?P<dev_l>dev)[-_\.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_\.][a-z0-9]+)*))?$`)
+
+var pep440PreLabels = map[string]string{
+	"a":       "a",
+	"alpha":   "a",
+	"b":       "b",
+	"beta":    "b",
+	"c":       "rc",
+	"rc":      "rc",
+	"pre":     "rc",
+	"preview": "rc",
+}
+
+// ParsePEP440 parses a Python package version. All the alternative spellings
+// allowed by PEP 440 are accepted and normalized.
+func ParsePEP440(version string) (PEP440, error) {
+	match := pep440Regex.FindStringSubmatch(strings.ToLower(strings.TrimSpace(version)))
+	if match == nil {
+		return PEP440{}, fmt.Errorf("invalid PEP 440 version %q", version)
+	}
+
+	group := func(name string) string {
+		return match[pep440Regex.SubexpIndex(name)]
+	}
+
+	// the regex ensures only digits, but the number might still overflow;
+	// the first overflow is remembered and reported once parsing is done
+	var numberErr error
+
+	number := func(s string) int64 {
+		if s == "" {
+			return 0
+		}
+
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil && numberErr == nil {
+			numberErr = fmt.Errorf("invalid PEP 440 version %q: number %s is out of range", version, s)
+		}
+
+		return n
+	}
+
+	result := PEP440{
+		Epoch: number(group("epoch")),
+	}
+
+	for _, part := range strings.Split(group("release"), ".") {
+		result.Release = append(result.Release, number(part))
+	}
+
+	if group("pre") != "" {
+		result.PreLabel = pep440PreLabels[group("pre_l")]
+		result.PreNumber = number(group("pre_n"))
+	}
+
+	if group("post") != "" {
+		result.HasPost = true
+		result.Post = number(group("post_n1") + group("post_n2"))
+	}
+
+	if group("dev") != "" {
+		result.HasDev = true
+		result.Dev = number(group("dev_n"))
+	}
+
+	if local := group("local"); local != "" {
+		result.Local = strings.FieldsFunc(local, func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+
+	if numberErr != nil {
+		return PEP440{}, numberErr
+	}
+
+	return result, nil
+}
+
+// String returns the normalized form of the version.
+func (v PEP440) String() string {
+	var sb strings.Builder
+
+	if v.Epoch != 0 {
+		sb.WriteString(fmt.Sprintf("%d!", v.Epoch))
+	}
+
+	for i, part := range v.Release {
+		if i > 0 {
+			sb.WriteString(".")
+		}
+
+		sb.WriteString(strconv.FormatInt(part, 10))
+	}
+
+	if v.PreLabel != "" {
+		sb.WriteString(fmt.Sprintf("%s%d", v.PreLabel, v.PreNumber))
+	}
+
+	if v.HasPost {
+		sb.WriteString(fmt.Sprintf(".post%d", v.Post))
+	}
+
+	if v.HasDev {
+		sb.WriteString(fmt.Sprintf(".dev%d", v.Dev))
+	}
+
+	if len(v.Local) > 0 {
+		sb.WriteString("+")
+		sb.WriteString(strings.Join(v.Local, "."))
+	}
+
+	return sb.String()
+}
+
+// DeepCopy implements deepcopy.Copier.
+func (v PEP440) DeepCopy() (any, error) {
+	result := v
+
+	result.Release = make([]int64, len(v.Release))
+	copy(result.Release, v.Release)
+
+	if v.Local != nil {
+		result.Local = make([]string, len(v.Local))
+		copy(result.Local, v.Local)
+	}
+
+	return result, nil
+}
+
+// CoalesceToString implements coalescing.CustomStringCoalescer.
+func (v PEP440) CoalesceToString(_ coalescing.Coalescer) (string, error) {
+	return v.String(), nil
+}
+
+// Compare implements equality.Comparer, following the ordering rules of
+// PEP 440 (e.g. "1.0.dev0" < "1.0a1" < "1.0" < "1.0.post1").
+func (v PEP440) Compare(other any) (int, error) {
+	otherV, ok := other.(PEP440)
+	if !ok {
+		return 0, equality.ErrIncompatibleTypes
+	}
+
+	if c := compareInt64(v.Epoch, otherV.Epoch); c != 0 {
+		return c, nil
+	}
+
+	if c := compareReleases(v.Release, otherV.Release); c != 0 {
+		return c, nil
+	}
+
+	if c := compareInt64Slices(v.preKey(), otherV.preKey()); c != 0 {
+		return c, nil
+	}
+
+	if c := compareOptional(v.HasPost, v.Post, otherV.HasPost, otherV.Post, false); c != 0 {
+		return c, nil
+	}
+
+	if c := compareOptional(v.HasDev, v.Dev, otherV.HasDev, otherV.Dev, true); c != 0 {
+		return c, nil
+	}
+
+	return compareLocals(v.Local, otherV.Local), nil
+}
+
+// preKey returns a sortable representation of the prerelease segment.
+func (v PEP440) preKey() []int64 {
+	switch {
+	// a dev release of a final version sorts before all of its prereleases
+	case v.PreLabel == "" && !v.HasPost && v.HasDev:
+		return []int64{-1}
+	// final releases sort after all of their prereleases
+	case v.PreLabel == "":
+		return []int64{3}
+	case v.PreLabel == "a":
+		return []int64{0, v.PreNumber}
+	case v.PreLabel == "b":
+		return []int64{1, v.PreNumber}
+	default:
+		return []int64{2, v.PreNumber}
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInt64Slices(a, b []int64) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareInt64(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInt64(int64(len(a)), int64(len(b)))
+}
+
+// compareReleases compares release segments, ignoring trailing zeros
+// (i.e. "1.0" == "1.0.0").
+func compareReleases(a, b []int64) int {
+	trim := func(s []int64) []int64 {
+		for len(s) > 0 && s[len(s)-1] == 0 {
+			s = s[:len(s)-1]
+		}
+
+		return s
+	}
+
+	return compareInt64Slices(trim(a), trim(b))
+}
+
+// compareOptional compares two optional numbers; missingIsLarger determines
+// if a missing number sorts before or after all present numbers.
+func compareOptional(hasA bool, a int64, hasB bool, b int64, missingIsLarger bool) int {
+	missing := -1
+	if missingIsLarger {
+		missing = 1
+	}
+
+	switch {
+	case !hasA && !hasB:
+		return 0
+	case !hasA:
+		return missing
+	case !hasB:
+		return -missing
+	default:
+		return compareInt64(a, b)
+	}
+}
+
+// compareLocals compares local version labels: Versions without local labels
+// sort first, numeric segments sort after alphanumeric ones.
+func compareLocals(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		na, errA := strconv.ParseInt(a[i], 10, 64)
+		nb, errB := strconv.ParseInt(b[i], 10, 64)
+
+		var c int
+
+		switch {
+		case errA == nil && errB == nil:
+			c = compareInt64(na, nb)
+		case errA == nil:
+			c = 1
+		case errB == nil:
+			c = -1
+		default:
+			c = strings.Compare(a[i], b[i])
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return compareInt64(int64(len(a)), int64(len(b)))
+}
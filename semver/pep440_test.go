@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"testing"
+)
+
+func TestParsePEP440(t *testing.T) {
+	testcases := []struct {
+		version  string
+		expected string
+		invalid  bool
+	}{
+		{version: "1.0", expected: "1.0"},
+		{version: "v1.0", expected: "1.0"},
+		{version: "1!2.0", expected: "1!2.0"},
+		{version: "1.0.post1", expected: "1.0.post1"},
+		{version: "1.0-1", expected: "1.0.post1"},
+		{version: "1.0-r2", expected: "1.0.post2"},
+		{version: "1.0post", expected: "1.0.post0"},
+		{version: "2.0rc1", expected: "2.0rc1"},
+		{version: "2.0-RC.1", expected: "2.0rc1"},
+		{version: "2.0.alpha", expected: "2.0a0"},
+		{version: "2.0beta2", expected: "2.0b2"},
+		{version: "2.0c3", expected: "2.0rc3"},
+		{version: "2.0preview3", expected: "2.0rc3"},
+		{version: "1.0.dev", expected: "1.0.dev0"},
+		{version: "1.0a1.post2.dev3", expected: "1.0a1.post2.dev3"},
+		{version: "1.0+ubuntu-1_2", expected: "1.0+ubuntu.1.2"},
+		{version: "", invalid: true},
+		{version: "foo", invalid: true},
+		{version: "1.0+", invalid: true},
+		{version: "1.0gamma", invalid: true},
+		{version: "1.99999999999999999999", invalid: true},
+		{version: "99999999999999999999!1.0", invalid: true},
+		{version: "1.0.post99999999999999999999", invalid: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.version, func(t *testing.T) {
+			parsed, err := ParsePEP440(testcase.version)
+			if err != nil {
+				if !testcase.invalid {
+					t.Fatalf("Failed to parse version: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.invalid {
+				t.Fatalf("Expected error, but got %s", parsed)
+			}
+
+			if s := parsed.String(); s != testcase.expected {
+				t.Fatalf("Expected %q, but got %q", testcase.expected, s)
+			}
+		})
+	}
+}
+
+func TestComparePEP440(t *testing.T) {
+	// sorted in ascending order, taken from the examples in PEP 440
+	ordered := []string{
+		"1.0.dev456",
+		"1.0a1",
+		"1.0a2.dev456",
+		"1.0a12.dev456",
+		"1.0a12",
+		"1.0b1.dev456",
+		"1.0b2",
+		"1.0b2.post345.dev456",
+		"1.0b2.post345",
+		"1.0rc1.dev456",
+		"1.0rc1",
+		"1.0",
+		"1.0+abc.5",
+		"1.0+abc.7",
+		"1.0+5",
+		"1.0.post456.dev34",
+		"1.0.post456",
+		"1.0.15",
+		"1.1.dev1",
+		"1!0.1",
+	}
+
+	for i := range ordered {
+		a, err := ParsePEP440(ordered[i])
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", ordered[i], err)
+		}
+
+		for j := range ordered {
+			b, err := ParsePEP440(ordered[j])
+			if err != nil {
+				t.Fatalf("Failed to parse %q: %v", ordered[j], err)
+			}
+
+			result, err := a.Compare(b)
+			if err != nil {
+				t.Fatalf("Failed to compare: %v", err)
+			}
+
+			if expected := compareInt64(int64(i), int64(j)); result != expected {
+				t.Errorf("Expected %s <=> %s to be %d, but got %d", ordered[i], ordered[j], expected, result)
+			}
+		}
+	}
+
+	a, _ := ParsePEP440("1.0")
+	b, _ := ParsePEP440("1.0.0")
+
+	if result, _ := a.Compare(b); result != 0 {
+		t.Errorf("Expected 1.0 and 1.0.0 to be equal, but got %d", result)
+	}
+}
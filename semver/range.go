@@ -0,0 +1,397 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+var (
+	partialVersionRegex = regexp.MustCompile(`^[vV]?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+)
+
+type Dialect string
+
+const (
+	// DialectNPM is the range syntax used by npm (and also understood by many
+	// other tools), with "||" for alternatives, whitespace separated
+	// comparators and hyphen ranges ("1.2 - 1.4").
+	DialectNPM Dialect = "npm"
+	// DialectCargo is the requirement syntax used by Cargo, with comma
+	// separated comparators where a bare version means a caret requirement.
+	DialectCargo Dialect = "cargo"
+)
+
+// ParseRange parses a constraint string like ">=1.2.0 <2.0.0 || ^3.1" into a
+// Range. Whitespace separated comparators are ANDed, "||" separates
+// alternatives. Besides the basic operators (=, >, >=, <, <=), caret (^),
+// tilde (~), X-ranges (1.x, 1.2.*) and hyphen ranges (1.2 - 1.4) are
+// supported and expanded into basic comparators.
+func ParseRange(constraint string) (Range, error) {
+	return ParseRangeDialect(constraint, DialectNPM)
+}
+
+// ParseRangeDialect parses a constraint string in the given dialect. Both
+// dialects are normalized into the same comparators.
+func ParseRangeDialect(constraint string, dialect Dialect) (Range, error) {
+	switch dialect {
+	case DialectNPM:
+		return parseNPMRange(constraint)
+	case DialectCargo:
+		return parseCargoRange(constraint)
+	default:
+		return Range{}, fmt.Errorf("unknown dialect %q", dialect)
+	}
+}
+
+func parseNPMRange(constraint string) (Range, error) {
+	result := Range{
+		Sets: [][]Comparator{},
+	}
+
+	for _, alternative := range strings.Split(constraint, "||") {
+		set, err := parseNPMComparatorSet(alternative)
+		if err != nil {
+			return Range{}, err
+		}
+
+		result.Sets = append(result.Sets, set)
+	}
+
+	return result, nil
+}
+
+func parseNPMComparatorSet(s string) ([]Comparator, error) {
+	set := []Comparator{}
+	tokens := tokenizeComparators(s)
+
+	for i := 0; i < len(tokens); i++ {
+		var (
+			comparators []Comparator
+			err         error
+		)
+
+		if i+2 < len(tokens) && tokens[i+1] == "-" {
+			comparators, err = parseHyphenRange(tokens[i], tokens[i+2])
+			i += 2
+		} else {
+			comparators, err = parseComparator(tokens[i], "")
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		set = append(set, comparators...)
+	}
+
+	return set, nil
+}
+
+// tokenizeComparators splits a comparator set at whitespace, but keeps
+// operators that are separated from their version (">= 1.2") together.
+func tokenizeComparators(s string) []string {
+	tokens := []string{}
+	pending := ""
+
+	for _, field := range strings.Fields(s) {
+		if strings.Trim(field, "<>=^~") == "" {
+			pending += field
+			continue
+		}
+
+		tokens = append(tokens, pending+field)
+		pending = ""
+	}
+
+	if pending != "" {
+		tokens = append(tokens, pending)
+	}
+
+	return tokens
+}
+
+func parseHyphenRange(from string, to string) ([]Comparator, error) {
+	lower, err := parsePartialVersion(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint %q: %w", from+" - "+to, err)
+	}
+
+	upper, err := parsePartialVersion(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint %q: %w", from+" - "+to, err)
+	}
+
+	return append(lower.greaterEqual(), upper.lessEqual()...), nil
+}
+
+func parseCargoRange(constraint string) (Range, error) {
+	if strings.Contains(constraint, "||") {
+		return Range{}, fmt.Errorf("invalid constraint %q: alternatives are not supported in Cargo requirements", constraint)
+	}
+
+	set := []Comparator{}
+
+	if strings.TrimSpace(constraint) != "" {
+		for _, token := range strings.Split(constraint, ",") {
+			comparators, err := parseComparator(strings.Join(strings.Fields(token), ""), "^")
+			if err != nil {
+				return Range{}, err
+			}
+
+			set = append(set, comparators...)
+		}
+	}
+
+	return Range{
+		Sets: [][]Comparator{set},
+	}, nil
+}
+
+// FormatRange prints a range in the given dialect. Since Cargo does not
+// support alternatives, only ranges with a single comparator set can be
+// printed as Cargo requirements.
+func FormatRange(r Range, dialect Dialect) (string, error) {
+	switch dialect {
+	case DialectNPM:
+		return r.String(), nil
+	case DialectCargo:
+		switch len(r.Sets) {
+		case 0:
+			return nothing().String(), nil
+		case 1:
+			if len(r.Sets[0]) == 0 {
+				return "*", nil
+			}
+
+			comparators := make([]string, len(r.Sets[0]))
+			for i, c := range r.Sets[0] {
+				comparators[i] = c.String()
+			}
+
+			return strings.Join(comparators, ", "), nil
+		default:
+			return "", fmt.Errorf("range %q has alternatives, which cannot be expressed as a Cargo requirement", r.String())
+		}
+	default:
+		return "", fmt.Errorf("unknown dialect %q", dialect)
+	}
+}
+
+// parseComparator parses a single comparator; if the token has no operator,
+// the defaultOp is used.
+func parseComparator(token string, defaultOp string) ([]Comparator, error) {
+	version := strings.TrimLeft(token, "<>=^~")
+	op := token[:len(token)-len(version)]
+	if op == "" {
+		op = defaultOp
+	}
+
+	p, err := parsePartialVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint %q: %w", token, err)
+	}
+
+	switch op {
+	case "", "=", "==":
+		return p.exact(), nil
+	case ">":
+		return p.greater(), nil
+	case ">=":
+		return p.greaterEqual(), nil
+	case "<":
+		return p.less(), nil
+	case "<=":
+		return p.lessEqual(), nil
+	case "~", "~>":
+		return p.tilde(), nil
+	case "^":
+		return p.caret(), nil
+	default:
+		return nil, fmt.Errorf("invalid constraint %q: unknown operator %q", token, op)
+	}
+}
+
+// partialVersion is a version where the less significant components can be
+// missing (or wildcards), like "1.2" or "1.x".
+type partialVersion struct {
+	version blangsemver.Version
+	// components is the number of components that were given (0-3).
+	components int
+}
+
+func parsePartialVersion(s string) (partialVersion, error) {
+	match := partialVersionRegex.FindStringSubmatch(s)
+	if match == nil {
+		return partialVersion{}, fmt.Errorf("%q is not a valid version", s)
+	}
+
+	p := partialVersion{}
+	numbers := []*uint64{&p.version.Major, &p.version.Minor, &p.version.Patch}
+
+	for i, component := range match[1:4] {
+		if component == "" || strings.ContainsAny(component, "xX*") {
+			break
+		}
+
+		n, err := strconv.ParseUint(component, 10, 64)
+		if err != nil {
+			return partialVersion{}, fmt.Errorf("%q is not a valid version: %w", s, err)
+		}
+
+		*numbers[i] = n
+		p.components++
+	}
+
+	if match[4] != "" || match[5] != "" {
+		if p.components < 3 {
+			return partialVersion{}, fmt.Errorf("%q is not a valid version: prerelease and build metadata require a full version", s)
+		}
+
+		// let blang do the validation of the identifiers
+		full, err := blangsemver.Parse(strings.TrimLeft(s, "vV"))
+		if err != nil {
+			return partialVersion{}, fmt.Errorf("%q is not a valid version: %w", s, err)
+		}
+
+		p.version = full
+	}
+
+	return p, nil
+}
+
+// next returns the smallest version that is larger than all versions matched
+// by the partial version, i.e. "1.2" => "1.3.0-0".
+func (p partialVersion) next() blangsemver.Version {
+	switch p.components {
+	case 1:
+		return upperBound(p.version.Major+1, 0, 0)
+	case 2:
+		return upperBound(p.version.Major, p.version.Minor+1, 0)
+	default:
+		return upperBound(p.version.Major, p.version.Minor, p.version.Patch+1)
+	}
+}
+
+func (p partialVersion) exact() []Comparator {
+	switch p.components {
+	case 0:
+		return []Comparator{}
+	case 3:
+		return []Comparator{{Operator: OpEqual, Version: p.version}}
+	default:
+		return []Comparator{
+			{Operator: OpGreaterEqual, Version: p.version},
+			{Operator: OpLess, Version: p.next()},
+		}
+	}
+}
+
+func (p partialVersion) greater() []Comparator {
+	switch p.components {
+	case 0:
+		return []Comparator{nothing()}
+	case 3:
+		return []Comparator{{Operator: OpGreater, Version: p.version}}
+	default:
+		next := p.next()
+		next.Pre = nil
+
+		return []Comparator{{Operator: OpGreaterEqual, Version: next}}
+	}
+}
+
+func (p partialVersion) greaterEqual() []Comparator {
+	if p.components == 0 {
+		return []Comparator{}
+	}
+
+	return []Comparator{{Operator: OpGreaterEqual, Version: p.version}}
+}
+
+func (p partialVersion) less() []Comparator {
+	switch p.components {
+	case 0:
+		return []Comparator{nothing()}
+	case 3:
+		return []Comparator{{Operator: OpLess, Version: p.version}}
+	default:
+		return []Comparator{{Operator: OpLess, Version: upperBound(p.version.Major, p.version.Minor, p.version.Patch)}}
+	}
+}
+
+func (p partialVersion) lessEqual() []Comparator {
+	switch p.components {
+	case 0:
+		return []Comparator{}
+	case 3:
+		return []Comparator{{Operator: OpLessEqual, Version: p.version}}
+	default:
+		return []Comparator{{Operator: OpLess, Version: p.next()}}
+	}
+}
+
+// tilde allows patch-level changes if a minor version is specified, and
+// minor-level changes if not.
+func (p partialVersion) tilde() []Comparator {
+	var upper blangsemver.Version
+
+	switch p.components {
+	case 0:
+		return []Comparator{}
+	case 1:
+		upper = upperBound(p.version.Major+1, 0, 0)
+	default:
+		upper = upperBound(p.version.Major, p.version.Minor+1, 0)
+	}
+
+	return []Comparator{
+		{Operator: OpGreaterEqual, Version: p.version},
+		{Operator: OpLess, Version: upper},
+	}
+}
+
+// caret allows changes that do not modify the left-most non-zero component.
+func (p partialVersion) caret() []Comparator {
+	var upper blangsemver.Version
+
+	v := p.version
+
+	switch {
+	case p.components == 0:
+		return []Comparator{}
+	case v.Major > 0 || p.components == 1:
+		upper = upperBound(v.Major+1, 0, 0)
+	case v.Minor > 0 || p.components == 2:
+		upper = upperBound(0, v.Minor+1, 0)
+	default:
+		upper = upperBound(0, 0, v.Patch+1)
+	}
+
+	return []Comparator{
+		{Operator: OpGreaterEqual, Version: v},
+		{Operator: OpLess, Version: upper},
+	}
+}
+
+// upperBound is used to build exclusive upper bounds like "<2.0.0-0", which
+// exclude all prereleases of 2.0.0 as well.
+func upperBound(major, minor, patch uint64) blangsemver.Version {
+	return blangsemver.Version{
+		Major: major,
+		Minor: minor,
+		Patch: patch,
+		Pre:   []blangsemver.PRVersion{{VersionNum: 0, IsNum: true}},
+	}
+}
+
+// nothing returns a comparator that cannot be satisfied by any version.
+func nothing() Comparator {
+	return Comparator{Operator: OpLess, Version: upperBound(0, 0, 0)}
+}
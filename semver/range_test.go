@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	testcases := []struct {
+		constraint string
+		expected   string
+		invalid    bool
+	}{
+		{constraint: "", expected: "*"},
+		{constraint: "*", expected: "*"},
+		{constraint: "1.2.3", expected: "=1.2.3"},
+		{constraint: "v1.2.3", expected: "=1.2.3"},
+		{constraint: "=1.2", expected: ">=1.2.0 <1.3.0-0"},
+		{constraint: "1.x", expected: ">=1.0.0 <2.0.0-0"},
+		{constraint: "1.2.*", expected: ">=1.2.0 <1.3.0-0"},
+		{constraint: ">1.2", expected: ">=1.3.0"},
+		{constraint: ">1.2.3", expected: ">1.2.3"},
+		{constraint: ">= 1.2", expected: ">=1.2.0"},
+		{constraint: "<1.2", expected: "<1.2.0-0"},
+		{constraint: "<=1.2", expected: "<1.3.0-0"},
+		{constraint: "<=1.2.3", expected: "<=1.2.3"},
+		{constraint: "~1.2.3", expected: ">=1.2.3 <1.3.0-0"},
+		{constraint: "~1", expected: ">=1.0.0 <2.0.0-0"},
+		{constraint: "^1.2.3-beta.1", expected: ">=1.2.3-beta.1 <2.0.0-0"},
+		{constraint: "^0.2.3", expected: ">=0.2.3 <0.3.0-0"},
+		{constraint: "^0.0.3", expected: ">=0.0.3 <0.0.4-0"},
+		{constraint: "^0.0", expected: ">=0.0.0 <0.1.0-0"},
+		{constraint: ">=1.2.0 <2.0.0 || ^3.1", expected: ">=1.2.0 <2.0.0 || >=3.1.0 <4.0.0-0"},
+		{constraint: "foo", invalid: true},
+		{constraint: "1.2-beta", invalid: true},
+		{constraint: "!1.2", invalid: true},
+		{constraint: "1.2 ||", expected: ">=1.2.0 <1.3.0-0 || *"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.constraint, func(t *testing.T) {
+			r, err := ParseRange(testcase.constraint)
+			if err != nil {
+				if !testcase.invalid {
+					t.Fatalf("Failed to parse range: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.invalid {
+				t.Fatalf("Expected error, but got %q", r.String())
+			}
+
+			if s := r.String(); s != testcase.expected {
+				t.Fatalf("Expected %q, but got %q", testcase.expected, s)
+			}
+		})
+	}
+}
+
+func TestParseRangeDialect(t *testing.T) {
+	testcases := []struct {
+		constraint string
+		dialect    Dialect
+		expected   string
+		cargo      string
+		invalid    bool
+	}{
+		{constraint: "1.2 - 1.4", dialect: DialectNPM, expected: ">=1.2.0 <1.5.0-0", cargo: ">=1.2.0, <1.5.0-0"},
+		{constraint: "1.2.3 - 1.4.5", dialect: DialectNPM, expected: ">=1.2.3 <=1.4.5", cargo: ">=1.2.3, <=1.4.5"},
+		{constraint: ">=1 <2 || 3", dialect: DialectNPM, expected: ">=1.0.0 <2.0.0-0 || >=3.0.0 <4.0.0-0"},
+		{constraint: "1.2 - ", dialect: DialectNPM, invalid: true},
+		{constraint: "1.2", dialect: DialectCargo, expected: ">=1.2.0 <2.0.0-0", cargo: ">=1.2.0, <2.0.0-0"},
+		{constraint: "0.2.3", dialect: DialectCargo, expected: ">=0.2.3 <0.3.0-0", cargo: ">=0.2.3, <0.3.0-0"},
+		{constraint: "=1.2.3", dialect: DialectCargo, expected: "=1.2.3", cargo: "=1.2.3"},
+		{constraint: ">= 1.2, < 1.5", dialect: DialectCargo, expected: ">=1.2.0 <1.5.0-0", cargo: ">=1.2.0, <1.5.0-0"},
+		{constraint: "~1.2.3, 1.x", dialect: DialectCargo, expected: ">=1.2.3 <1.3.0-0 >=1.0.0 <2.0.0-0", cargo: ">=1.2.3, <1.3.0-0, >=1.0.0, <2.0.0-0"},
+		{constraint: "*", dialect: DialectCargo, expected: "*", cargo: "*"},
+		{constraint: "", dialect: DialectCargo, expected: "*", cargo: "*"},
+		{constraint: "1.2,", dialect: DialectCargo, invalid: true},
+		{constraint: "1 || 2", dialect: DialectCargo, invalid: true},
+		{constraint: "1", dialect: "foo", invalid: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(string(testcase.dialect)+" "+testcase.constraint, func(t *testing.T) {
+			r, err := ParseRangeDialect(testcase.constraint, testcase.dialect)
+			if err != nil {
+				if !testcase.invalid {
+					t.Fatalf("Failed to parse range: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.invalid {
+				t.Fatalf("Expected error, but got %q", r.String())
+			}
+
+			if s := r.String(); s != testcase.expected {
+				t.Fatalf("Expected %q, but got %q", testcase.expected, s)
+			}
+
+			cargo, err := FormatRange(r, DialectCargo)
+			if err != nil {
+				if testcase.cargo != "" {
+					t.Fatalf("Failed to format range: %v", err)
+				}
+
+				return
+			}
+
+			if cargo != testcase.cargo {
+				t.Fatalf("Expected Cargo requirement %q, but got %q", testcase.cargo, cargo)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"fmt"
+	"sort"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+// SkewRule defines how many minor versions a component may be older or newer
+// than the control plane.
+type SkewRule struct {
+	Older int64
+	Newer int64
+}
+
+// SkewPolicy maps component names to their skew rules.
+type SkewPolicy map[string]SkewRule
+
+// DefaultSkewPolicy is the Kubernetes version skew policy as of v1.28, see
+// https://kubernetes.io/releases/version-skew-policy/.
+var DefaultSkewPolicy = SkewPolicy{
+	"kube-apiserver":           {Older: 1},
+	"kube-controller-manager":  {Older: 1},
+	"kube-scheduler":           {Older: 1},
+	"cloud-controller-manager": {Older: 1},
+	"kubelet":                  {Older: 3},
+	"kube-proxy":               {Older: 3},
+	"kubectl":                  {Older: 1, Newer: 1},
+}
+
+// CheckSkew compares the versions of all components to the control plane
+// version and returns a description of every violation of the policy, sorted
+// by component name. Components that are not part of the policy result in an
+// error.
+func CheckSkew(controlPlane blangsemver.Version, components map[string]blangsemver.Version, policy SkewPolicy) ([]string, error) {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	violations := []string{}
+
+	for _, name := range names {
+		rule, ok := policy[name]
+		if !ok {
+			return nil, fmt.Errorf("no skew policy defined for component %q", name)
+		}
+
+		version := components[name]
+
+		if version.Major != controlPlane.Major {
+			violations = append(violations, fmt.Sprintf("%s %s has a different major version than the control plane %s", name, version, controlPlane))
+			continue
+		}
+
+		skew := int64(controlPlane.Minor) - int64(version.Minor)
+
+		switch {
+		case skew > rule.Older:
+			violations = append(violations, fmt.Sprintf("%s %s is %d minor versions older than the control plane %s (at most %d allowed)", name, version, skew, controlPlane, rule.Older))
+		case -skew > rule.Newer:
+			violations = append(violations, fmt.Sprintf("%s %s is %d minor versions newer than the control plane %s (at most %d allowed)", name, version, -skew, controlPlane, rule.Newer))
+		}
+	}
+
+	return violations, nil
+}
+
+// MinorSteps returns all minor versions ("MAJOR.MINOR") after from, up to and
+// including to, i.e. the steps of an upgrade path that must not skip minor
+// versions.
+func MinorSteps(from, to blangsemver.Version) ([]string, error) {
+	if from.Major != to.Major {
+		return nil, fmt.Errorf("cannot enumerate minor versions between different major versions (%s and %s)", from, to)
+	}
+
+	if to.Minor < from.Minor {
+		return nil, fmt.Errorf("%s is older than %s", to, from)
+	}
+
+	steps := []string{}
+	for minor := from.Minor + 1; minor <= to.Minor; minor++ {
+		steps = append(steps, fmt.Sprintf("%d.%d", to.Major, minor))
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"reflect"
+	"testing"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+func TestCheckSkew(t *testing.T) {
+	controlPlane := blangsemver.MustParse("1.28.3")
+
+	testcases := []struct {
+		name       string
+		components map[string]string
+		violations int
+		invalid    bool
+	}{
+		{
+			name: "all fine",
+			components: map[string]string{
+				"kube-apiserver": "1.27.0",
+				"kubelet":        "1.25.9",
+				"kubectl":        "1.29.0",
+			},
+		},
+		{
+			name: "too old and too new",
+			components: map[string]string{
+				"kube-scheduler": "1.26.0",
+				"kubelet":        "1.24.0",
+				"kube-proxy":     "1.29.0",
+			},
+			violations: 3,
+		},
+		{
+			name: "different major version",
+			components: map[string]string{
+				"kubelet": "2.28.0",
+			},
+			violations: 1,
+		},
+		{
+			name: "unknown component",
+			components: map[string]string{
+				"foo": "1.28.0",
+			},
+			invalid: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			components := map[string]blangsemver.Version{}
+			for name, version := range testcase.components {
+				components[name] = blangsemver.MustParse(version)
+			}
+
+			violations, err := CheckSkew(controlPlane, components, DefaultSkewPolicy)
+			if err != nil {
+				if !testcase.invalid {
+					t.Fatalf("Failed to check skew: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.invalid {
+				t.Fatalf("Expected error, but got %v", violations)
+			}
+
+			if len(violations) != testcase.violations {
+				t.Fatalf("Expected %d violations, but got %v", testcase.violations, violations)
+			}
+		})
+	}
+}
+
+func TestMinorSteps(t *testing.T) {
+	testcases := []struct {
+		from, to string
+		expected []string
+		invalid  bool
+	}{
+		{from: "1.25.3", to: "1.28.0", expected: []string{"1.26", "1.27", "1.28"}},
+		{from: "1.25.3", to: "1.26.1", expected: []string{"1.26"}},
+		{from: "1.25.3", to: "1.25.9", expected: []string{}},
+		{from: "1.25.3", to: "1.24.0", invalid: true},
+		{from: "1.25.3", to: "2.0.0", invalid: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.from+" "+testcase.to, func(t *testing.T) {
+			steps, err := MinorSteps(blangsemver.MustParse(testcase.from), blangsemver.MustParse(testcase.to))
+			if err != nil {
+				if !testcase.invalid {
+					t.Fatalf("Failed to determine steps: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.invalid {
+				t.Fatalf("Expected error, but got %v", steps)
+			}
+
+			if !reflect.DeepEqual(testcase.expected, steps) {
+				t.Fatalf("Expected %v, but got %v", testcase.expected, steps)
+			}
+		})
+	}
+}
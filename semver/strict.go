@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+// ParseError describes why a string is not a strictly valid semantic version.
+type ParseError struct {
+	// Input is the string that was parsed.
+	Input string
+	// Component is the part of the version that is invalid, one of "prefix",
+	// "major", "minor", "patch", "prerelease" or "build".
+	Component string
+	// Position is the byte offset in Input where the problem was found.
+	Position int
+	// Reason is a human readable description of the problem.
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid semver %q: %s %s (at position %d)", e.Input, e.Component, e.Reason, e.Position)
+}
+
+var coreComponents = []string{"major", "minor", "patch"}
+
+// ParseStrict parses a version according to the semver 2.0.0 specification,
+// without any of the leniency of blangsemver.ParseTolerant: leading "v"s,
+// missing components and leading zeros are rejected. If the version is
+// invalid, a *ParseError is returned.
+func ParseStrict(s string) (blangsemver.Version, error) {
+	fail := func(component string, pos int, reason string) (blangsemver.Version, error) {
+		return blangsemver.Version{}, &ParseError{
+			Input:     s,
+			Component: component,
+			Position:  pos,
+			Reason:    reason,
+		}
+	}
+
+	if strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V") {
+		return fail("prefix", 0, `must not start with "v"`)
+	}
+
+	coreEnd := strings.IndexAny(s, "-+")
+	if coreEnd == -1 {
+		coreEnd = len(s)
+	}
+
+	pos := 0
+	for i, part := range strings.Split(s[:coreEnd], ".") {
+		if i >= len(coreComponents) {
+			return fail("patch", pos-1, "must not be followed by further components")
+		}
+
+		if reason := checkNumericIdentifier(part); reason != "" {
+			return fail(coreComponents[i], pos, reason)
+		}
+
+		pos += len(part) + 1
+	}
+
+	if components := strings.Count(s[:coreEnd], ".") + 1; components < len(coreComponents) {
+		return fail(coreComponents[components], coreEnd, "is missing")
+	}
+
+	if coreEnd < len(s) && s[coreEnd] == '-' {
+		preEnd := strings.IndexByte(s, '+')
+		if preEnd == -1 {
+			preEnd = len(s)
+		}
+
+		if reason, offset := checkIdentifiers(s[coreEnd+1:preEnd], true); reason != "" {
+			return fail("prerelease", coreEnd+1+offset, reason)
+		}
+
+		coreEnd = preEnd
+	}
+
+	if coreEnd < len(s) {
+		if reason, offset := checkIdentifiers(s[coreEnd+1:], false); reason != "" {
+			return fail("build", coreEnd+1+offset, reason)
+		}
+	}
+
+	return blangsemver.Parse(s)
+}
+
+func checkNumericIdentifier(s string) string {
+	switch {
+	case s == "":
+		return "must not be empty"
+	case strings.Trim(s, "0123456789") != "":
+		return "must be numeric"
+	case len(s) > 1 && s[0] == '0':
+		return "must not contain leading zeros"
+	case !fitsUint64(s):
+		return "is out of range"
+	default:
+		return ""
+	}
+}
+
+func fitsUint64(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// checkIdentifiers validates a dot-separated list of prerelease or build
+// identifiers and returns the reason and offset of the first problem.
+func checkIdentifiers(s string, prerelease bool) (string, int) {
+	offset := 0
+
+	for _, identifier := range strings.Split(s, ".") {
+		if identifier == "" {
+			return "identifiers must not be empty", offset
+		}
+
+		if idx := strings.IndexFunc(identifier, func(r rune) bool {
+			return !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-')
+		}); idx != -1 {
+			return "identifiers must only contain [0-9A-Za-z-]", offset + idx
+		}
+
+		if prerelease && strings.Trim(identifier, "0123456789") == "" {
+			if len(identifier) > 1 && identifier[0] == '0' {
+				return "numeric identifiers must not contain leading zeros", offset
+			}
+
+			if !fitsUint64(identifier) {
+				return "numeric identifiers must not be out of range", offset
+			}
+		}
+
+		offset += len(identifier) + 1
+	}
+
+	return "", 0
+}
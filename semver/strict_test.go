@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseStrict(t *testing.T) {
+	testcases := []struct {
+		version   string
+		component string
+		position  int
+	}{
+		{version: "1.2.3"},
+		{version: "0.0.0"},
+		{version: "1.2.3-rc.1+build.001"},
+		{version: "1.2.3-0a.b-c"},
+		{version: "v1.2.3", component: "prefix", position: 0},
+		{version: "", component: "major", position: 0},
+		{version: "1", component: "minor", position: 1},
+		{version: "1.2", component: "patch", position: 3},
+		{version: "1.2-rc.1", component: "patch", position: 3},
+		{version: "01.2.3", component: "major", position: 0},
+		{version: "1.02.3", component: "minor", position: 2},
+		{version: "1.2.03", component: "patch", position: 4},
+		{version: "1.x.3", component: "minor", position: 2},
+		{version: "1..3", component: "minor", position: 2},
+		{version: "1.2.3.4", component: "patch", position: 5},
+		{version: "1.2.3-", component: "prerelease", position: 6},
+		{version: "1.2.3-rc..1", component: "prerelease", position: 9},
+		{version: "1.2.3-rc.01", component: "prerelease", position: 9},
+		{version: "1.2.3-rc_1", component: "prerelease", position: 8},
+		{version: "99999999999999999999.0.0", component: "major", position: 0},
+		{version: "1.99999999999999999999.0", component: "minor", position: 2},
+		{version: "1.2.99999999999999999999", component: "patch", position: 4},
+		{version: "1.2.3-rc.99999999999999999999", component: "prerelease", position: 9},
+		{version: "1.2.3+99999999999999999999"},
+		{version: "1.2.3+", component: "build", position: 6},
+		{version: "1.2.3+abc.d_f", component: "build", position: 11},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.version, func(t *testing.T) {
+			parsed, err := ParseStrict(testcase.version)
+			if err != nil {
+				if testcase.component == "" {
+					t.Fatalf("Failed to parse version: %v", err)
+				}
+
+				var perr *ParseError
+				if !errors.As(err, &perr) {
+					t.Fatalf("Expected ParseError, but got %T: %v", err, err)
+				}
+
+				if perr.Component != testcase.component {
+					t.Errorf("Expected component %q, but got %q (%v)", testcase.component, perr.Component, err)
+				}
+
+				if perr.Position != testcase.position {
+					t.Errorf("Expected position %d, but got %d (%v)", testcase.position, perr.Position, err)
+				}
+
+				return
+			}
+
+			if testcase.component != "" {
+				t.Fatalf("Expected error in %s, but got %s", testcase.component, parsed)
+			}
+
+			if parsed.String() != testcase.version {
+				t.Fatalf("Expected %s, but got %s", testcase.version, parsed)
+			}
+		})
+	}
+}
@@ -4,42 +4,57 @@
 package semver
 
 import (
+	"fmt"
+	"strings"
+
 	blangsemver "github.com/blang/semver/v4"
 
 	"go.xrstf.de/rudi/pkg/coalescing"
 	"go.xrstf.de/rudi/pkg/deepcopy"
 	"go.xrstf.de/rudi/pkg/equality"
+	"go.xrstf.de/rudi/pkg/pathexpr"
 )
 
 type Semver struct {
 	Version blangsemver.Version
+
+	// CompareWithStrings makes the semver parse strings it is compared to, so
+	// that `(gt? $version "1.2")` works even with strict coalescing. Such
+	// semvers are created by the semver-comparable function.
+	CompareWithStrings bool
 }
 
 var (
 	_ deepcopy.Copier                  = Semver{}
 	_ coalescing.CustomStringCoalescer = Semver{}
 	_ equality.Comparer                = Semver{}
+	_ pathexpr.ObjectReader            = Semver{}
 )
 
 // DeepCopy implements deepcopy.Copier.
 func (v Semver) DeepCopy() (any, error) {
-	pres := make([]blangsemver.PRVersion, len(v.Version.Pre))
-	copy(pres, v.Version.Pre)
-
-	builds := make([]string, len(v.Version.Build))
-	copy(builds, v.Version.Build)
-
 	return Semver{
-		Version: blangsemver.Version{
-			Major: v.Version.Major,
-			Minor: v.Version.Minor,
-			Patch: v.Version.Patch,
-			Pre:   pres,
-			Build: builds,
-		},
+		Version:            copyVersion(v.Version),
+		CompareWithStrings: v.CompareWithStrings,
 	}, nil
 }
 
+func copyVersion(v blangsemver.Version) blangsemver.Version {
+	pres := make([]blangsemver.PRVersion, len(v.Pre))
+	copy(pres, v.Pre)
+
+	builds := make([]string, len(v.Build))
+	copy(builds, v.Build)
+
+	return blangsemver.Version{
+		Major: v.Major,
+		Minor: v.Minor,
+		Patch: v.Patch,
+		Pre:   pres,
+		Build: builds,
+	}
+}
+
 // CoalesceToString implements coalescing.CustomStringCoalescer.
 func (v Semver) CoalesceToString(_ coalescing.Coalescer) (string, error) {
 	return v.Version.String(), nil
@@ -47,10 +62,175 @@ func (v Semver) CoalesceToString(_ coalescing.Coalescer) (string, error) {
 
 // Equal implements equality.Comparer.
 func (v Semver) Compare(other any) (int, error) {
-	otherV, ok := other.(Semver)
-	if !ok {
+	switch otherV := other.(type) {
+	case Semver:
+		return v.Version.Compare(otherV.Version), nil
+
+	case string:
+		if !v.CompareWithStrings {
+			return 0, equality.ErrIncompatibleTypes
+		}
+
+		parsed, err := blangsemver.ParseTolerant(otherV)
+		if err != nil {
+			return 0, fmt.Errorf("cannot compare with %q: %w", otherV, equality.ErrIncompatibleTypes)
+		}
+
+		return v.Version.Compare(parsed), nil
+
+	default:
 		return 0, equality.ErrIncompatibleTypes
 	}
+}
+
+// GetObjectKey implements pathexpr.ObjectReader. The returned vectors are
+// copies, so modifying them does not change the semver.
+func (v Semver) GetObjectKey(name string) (any, error) {
+	switch name {
+	case "major":
+		return int64(v.Version.Major), nil
+	case "minor":
+		return int64(v.Version.Minor), nil
+	case "patch":
+		return int64(v.Version.Patch), nil
+	case "pre":
+		pres := make([]any, len(v.Version.Pre))
+		for i, pre := range v.Version.Pre {
+			if pre.IsNum {
+				pres[i] = int64(pre.VersionNum)
+			} else {
+				pres[i] = pre.VersionStr
+			}
+		}
 
-	return v.Version.Compare(otherV.Version), nil
+		return pres, nil
+	case "build":
+		builds := make([]any, len(v.Version.Build))
+		for i, build := range v.Version.Build {
+			builds[i] = build
+		}
+
+		return builds, nil
+	case "prerelease?":
+		return len(v.Version.Pre) > 0, nil
+	default:
+		return nil, fmt.Errorf("semver has no field %q", name)
+	}
+}
+
+type Operator string
+
+const (
+	OpEqual        Operator = "="
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+)
+
+type Comparator struct {
+	Operator Operator
+	Version  blangsemver.Version
+}
+
+// Matches returns true if the given version satisfies the comparator.
+func (c Comparator) Matches(v blangsemver.Version) bool {
+	cmp := v.Compare(c.Version)
+
+	switch c.Operator {
+	case OpEqual:
+		return cmp == 0
+	case OpGreater:
+		return cmp > 0
+	case OpGreaterEqual:
+		return cmp >= 0
+	case OpLess:
+		return cmp < 0
+	case OpLessEqual:
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+func (c Comparator) String() string {
+	return string(c.Operator) + c.Version.String()
+}
+
+// Range is a version constraint. Each set of comparators is one alternative
+// (i.e. all sets are ORed), while the comparators within each set are ANDed.
+// An empty comparator set matches all versions.
+type Range struct {
+	Sets [][]Comparator
+}
+
+var (
+	_ deepcopy.Copier                  = Range{}
+	_ coalescing.CustomStringCoalescer = Range{}
+)
+
+// Satisfies returns true if the version matches at least one of the
+// comparator sets.
+func (r Range) Satisfies(v blangsemver.Version) bool {
+	for _, set := range r.Sets {
+		if setMatches(set, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func setMatches(set []Comparator, v blangsemver.Version) bool {
+	for _, c := range set {
+		if !c.Matches(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r Range) String() string {
+	if len(r.Sets) == 0 {
+		return nothing().String()
+	}
+
+	alternatives := make([]string, len(r.Sets))
+	for i, set := range r.Sets {
+		if len(set) == 0 {
+			alternatives[i] = "*"
+			continue
+		}
+
+		comparators := make([]string, len(set))
+		for j, c := range set {
+			comparators[j] = c.String()
+		}
+
+		alternatives[i] = strings.Join(comparators, " ")
+	}
+
+	return strings.Join(alternatives, " || ")
+}
+
+// DeepCopy implements deepcopy.Copier.
+func (r Range) DeepCopy() (any, error) {
+	sets := make([][]Comparator, len(r.Sets))
+	for i, set := range r.Sets {
+		sets[i] = make([]Comparator, len(set))
+		for j, c := range set {
+			sets[i][j] = Comparator{
+				Operator: c.Operator,
+				Version:  copyVersion(c.Version),
+			}
+		}
+	}
+
+	return Range{Sets: sets}, nil
+}
+
+// CoalesceToString implements coalescing.CustomStringCoalescer.
+func (r Range) CoalesceToString(_ coalescing.Coalescer) (string, error) {
+	return r.String(), nil
 }
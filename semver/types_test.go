@@ -4,9 +4,13 @@
 package semver
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 
 	blangsemver "github.com/blang/semver/v4"
+
+	"go.xrstf.de/rudi/pkg/equality"
 )
 
 func TestSemverDeepCopy(t *testing.T) {
@@ -34,3 +38,115 @@ func TestSemverDeepCopy(t *testing.T) {
 		t.Fatal("Expected to only change copy, but changed original, too.")
 	}
 }
+
+func TestRangeDeepCopy(t *testing.T) {
+	input, err := ParseRange("^1.2.3-beta.1")
+	if err != nil {
+		t.Fatalf("Failed to parse range: %v", err)
+	}
+
+	copied, err := input.DeepCopy()
+	if err != nil {
+		t.Fatalf("Failed to deepcopy Range object: %v", err)
+	}
+
+	copiedRange, ok := copied.(Range)
+	if !ok {
+		t.Fatalf("DeepCopy did not return Range, but %T", copied)
+	}
+
+	if input.String() != copiedRange.String() {
+		t.Fatalf("Expected %s, but copy is %s", input.String(), copiedRange.String())
+	}
+
+	// change the copy
+	copiedRange.Sets[0][0].Version.Pre[0].VersionStr = "foo"
+
+	if input.String() == copiedRange.String() {
+		t.Fatal("Expected to only change copy, but changed original, too.")
+	}
+}
+
+func TestSemverGetObjectKey(t *testing.T) {
+	sv := Semver{Version: blangsemver.MustParse("1.2.3-rc.4+abc.def")}
+
+	testcases := []struct {
+		key      string
+		expected any
+	}{
+		{key: "major", expected: int64(1)},
+		{key: "minor", expected: int64(2)},
+		{key: "patch", expected: int64(3)},
+		{key: "pre", expected: []any{"rc", int64(4)}},
+		{key: "build", expected: []any{"abc", "def"}},
+		{key: "prerelease?", expected: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.key, func(t *testing.T) {
+			value, err := sv.GetObjectKey(testcase.key)
+			if err != nil {
+				t.Fatalf("Failed to get key: %v", err)
+			}
+
+			if !reflect.DeepEqual(testcase.expected, value) {
+				t.Fatalf("Expected %#v, but got %#v", testcase.expected, value)
+			}
+		})
+	}
+
+	if _, err := sv.GetObjectKey("foo"); err == nil {
+		t.Fatal("Expected error when accessing unknown key.")
+	}
+
+	// modifying the returned vectors must not affect the semver
+	pres, _ := sv.GetObjectKey("pre")
+	pres.([]any)[0] = "foo"
+
+	if sv.Version.Pre[0].VersionStr != "rc" {
+		t.Fatal("Expected to only change vector, but changed semver, too.")
+	}
+}
+
+func TestSemverCompareWithStrings(t *testing.T) {
+	sv := Semver{Version: blangsemver.MustParse("1.2.3")}
+
+	if _, err := sv.Compare("1.2.3"); !errors.Is(err, equality.ErrIncompatibleTypes) {
+		t.Fatalf("Expected strings to be incompatible by default, but got %v.", err)
+	}
+
+	sv.CompareWithStrings = true
+
+	if _, err := sv.Compare("foo"); !errors.Is(err, equality.ErrIncompatibleTypes) {
+		t.Fatalf("Expected invalid versions to be incompatible, but got %v.", err)
+	}
+
+	testcases := []struct {
+		other    string
+		expected int
+	}{
+		{other: "1.2.3", expected: 0},
+		{other: "v1.2", expected: 1},
+		{other: "1.3", expected: -1},
+		{other: "1.2.3-rc.1", expected: 1},
+	}
+
+	for _, testcase := range testcases {
+		result, err := sv.Compare(testcase.other)
+		if err != nil {
+			t.Fatalf("Failed to compare with %q: %v", testcase.other, err)
+		}
+
+		if result != testcase.expected {
+			t.Errorf("Expected comparison with %q to return %d, but got %d", testcase.other, testcase.expected, result)
+		}
+	}
+
+	if _, err := sv.Compare("foo"); err == nil {
+		t.Fatal("Expected error when comparing with invalid version.")
+	}
+
+	if _, err := sv.Compare(int64(1)); err == nil {
+		t.Fatal("Expected numbers to be incompatible.")
+	}
+}
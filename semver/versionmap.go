@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"fmt"
+	"sort"
+
+	blangsemver "github.com/blang/semver/v4"
+
+	"go.xrstf.de/rudi/pkg/deepcopy"
+	"go.xrstf.de/rudi/pkg/pathexpr"
+)
+
+// VersionMap is a map with semvers as keys, which keeps its entries sorted by
+// semantic version. Since build metadata is not relevant for precedence,
+// "1.0.0+a" and "1.0.0+b" are the same key. All modifications return a new
+// map and leave the original untouched.
+type VersionMap struct {
+	entries []VersionMapEntry
+}
+
+type VersionMapEntry struct {
+	Version blangsemver.Version
+	Value   any
+}
+
+var (
+	_ deepcopy.Copier       = VersionMap{}
+	_ pathexpr.ObjectReader = VersionMap{}
+)
+
+func NewVersionMap() VersionMap {
+	return VersionMap{
+		entries: []VersionMapEntry{},
+	}
+}
+
+// search returns the index of the entry for the given version and whether
+// such an entry exists; if not, the index is where it would be inserted.
+func (m VersionMap) search(v blangsemver.Version) (int, bool) {
+	idx := sort.Search(len(m.entries), func(i int) bool {
+		return m.entries[i].Version.Compare(v) >= 0
+	})
+
+	return idx, idx < len(m.entries) && m.entries[idx].Version.Compare(v) == 0
+}
+
+func (m VersionMap) Len() int {
+	return len(m.entries)
+}
+
+func (m VersionMap) Get(v blangsemver.Version) (any, bool) {
+	idx, found := m.search(v)
+	if !found {
+		return nil, false
+	}
+
+	return m.entries[idx].Value, true
+}
+
+// Set returns a copy of the map with the value stored for the given version,
+// replacing any previous entry for it.
+func (m VersionMap) Set(v blangsemver.Version, value any) VersionMap {
+	idx, found := m.search(v)
+
+	entries := make([]VersionMapEntry, 0, len(m.entries)+1)
+	entries = append(entries, m.entries[:idx]...)
+	entries = append(entries, VersionMapEntry{Version: v, Value: value})
+
+	if found {
+		idx++
+	}
+
+	entries = append(entries, m.entries[idx:]...)
+
+	return VersionMap{entries: entries}
+}
+
+// Delete returns a copy of the map without the entries for the given versions.
+func (m VersionMap) Delete(versions ...blangsemver.Version) VersionMap {
+	entries := make([]VersionMapEntry, 0, len(m.entries))
+
+	for _, entry := range m.entries {
+		deleted := false
+		for _, v := range versions {
+			if entry.Version.Compare(v) == 0 {
+				deleted = true
+				break
+			}
+		}
+
+		if !deleted {
+			entries = append(entries, entry)
+		}
+	}
+
+	return VersionMap{entries: entries}
+}
+
+// Entries returns a copy of all entries, sorted by version.
+func (m VersionMap) Entries() []VersionMapEntry {
+	entries := make([]VersionMapEntry, len(m.entries))
+	copy(entries, m.entries)
+
+	return entries
+}
+
+// DeepCopy implements deepcopy.Copier.
+func (m VersionMap) DeepCopy() (any, error) {
+	entries := make([]VersionMapEntry, len(m.entries))
+
+	for i, entry := range m.entries {
+		value, err := deepcopy.Clone(entry.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy value for %s: %w", entry.Version, err)
+		}
+
+		entries[i] = VersionMapEntry{
+			Version: copyVersion(entry.Version),
+			Value:   value,
+		}
+	}
+
+	return VersionMap{entries: entries}, nil
+}
+
+// GetObjectKey implements pathexpr.ObjectReader, so that entries can be
+// accessed like `$map["1.2.3"]`. Missing versions result in nil.
+func (m VersionMap) GetObjectKey(name string) (any, error) {
+	v, err := blangsemver.ParseTolerant(name)
+	if err != nil {
+		return nil, err
+	}
+
+	value, _ := m.Get(v)
+
+	return value, nil
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package semver
+
+import (
+	"testing"
+
+	blangsemver "github.com/blang/semver/v4"
+)
+
+func versionMapKeys(m VersionMap) []string {
+	keys := []string{}
+	for _, entry := range m.Entries() {
+		keys = append(keys, entry.Version.String())
+	}
+
+	return keys
+}
+
+func TestVersionMapOrder(t *testing.T) {
+	m := NewVersionMap()
+
+	for _, v := range []string{"1.10.0", "1.9.0", "1.9.0-rc.1", "2.0.0", "1.9.0+build"} {
+		m = m.Set(blangsemver.MustParse(v), v)
+	}
+
+	expected := []string{"1.9.0-rc.1", "1.9.0+build", "1.10.0", "2.0.0"}
+	keys := versionMapKeys(m)
+
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected %v, but got %v", expected, keys)
+	}
+
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Fatalf("Expected %v, but got %v", expected, keys)
+		}
+	}
+
+	value, ok := m.Get(blangsemver.MustParse("1.9.0"))
+	if !ok || value != "1.9.0+build" {
+		t.Fatalf("Expected 1.9.0 to be mapped to the latest value, but got %v", value)
+	}
+}
+
+func TestVersionMapImmutability(t *testing.T) {
+	m := NewVersionMap().Set(blangsemver.MustParse("1.0.0"), "a")
+
+	updated := m.Set(blangsemver.MustParse("2.0.0"), "b").Delete(blangsemver.MustParse("1.0.0"))
+
+	if m.Len() != 1 {
+		t.Fatalf("Original map should not have been modified, but has %d entries.", m.Len())
+	}
+
+	if keys := versionMapKeys(updated); len(keys) != 1 || keys[0] != "2.0.0" {
+		t.Fatalf("Expected [2.0.0], but got %v", keys)
+	}
+}
+
+func TestVersionMapGetObjectKey(t *testing.T) {
+	m := NewVersionMap().Set(blangsemver.MustParse("1.2.0"), "a")
+
+	value, err := m.GetObjectKey("v1.2")
+	if err != nil {
+		t.Fatalf("Failed to get key: %v", err)
+	}
+
+	if value != "a" {
+		t.Fatalf("Expected \"a\", but got %v", value)
+	}
+
+	value, err = m.GetObjectKey("1.3")
+	if err != nil {
+		t.Fatalf("Failed to get key: %v", err)
+	}
+
+	if value != nil {
+		t.Fatalf("Expected nil, but got %v", value)
+	}
+
+	if _, err := m.GetObjectKey("foo"); err == nil {
+		t.Fatal("Expected error for invalid version, but got none.")
+	}
+}
+
+func TestNewVersionMapDuplicateKeys(t *testing.T) {
+	// run multiple times, as map iteration order is random
+	for i := 0; i < 10; i++ {
+		_, err := newVersionMapFunction(nil, map[string]any{"1.0": 1, "v1.0.0": 2})
+		if err == nil {
+			t.Fatal("Should have rejected duplicate keys, but did not.")
+		}
+
+		expected := `argument #0: keys "1.0" and "v1.0.0" refer to the same version 1.0.0`
+		if err.Error() != expected {
+			t.Fatalf("Expected error %q, but got %q.", expected, err.Error())
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"go.xrstf.de/rudi/pkg/coalescing"
+	"go.xrstf.de/rudi/pkg/deepcopy"
+)
+
+// Bag is a multiset of strings, i.e. a set that counts how often each value
+// has been inserted.
+type Bag struct {
+	counts map[string]int64
+}
+
+var (
+	_ deepcopy.Copier                  = Bag{}
+	_ coalescing.CustomStringCoalescer = Bag{}
+	_ json.Marshaler                   = Bag{}
+)
+
+func NewBag(items ...string) Bag {
+	b := Bag{counts: map[string]int64{}}
+	b.insert(items...)
+
+	return b
+}
+
+// BagEntry is a value in a bag and how often it occurs.
+type BagEntry struct {
+	Value string
+	Count int64
+}
+
+// Count returns how often the value occurs in the bag.
+func (b Bag) Count(value string) int64 {
+	return b.counts[value]
+}
+
+// Len returns the number of distinct values in the bag.
+func (b Bag) Len() int {
+	return len(b.counts)
+}
+
+// Entries returns all values with their counts, sorted by count (highest
+// first) and then by value.
+func (b Bag) Entries() []BagEntry {
+	entries := make([]BagEntry, 0, len(b.counts))
+	for value, count := range b.counts {
+		entries = append(entries, BagEntry{Value: value, Count: count})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+
+		return entries[i].Value < entries[j].Value
+	})
+
+	return entries
+}
+
+// Equal returns true if both bags contain the same values with the same
+// counts. This also makes bags comparable using go-cmp.
+func (b Bag) Equal(other Bag) bool {
+	if len(b.counts) != len(other.counts) {
+		return false
+	}
+
+	for value, count := range b.counts {
+		if other.counts[value] != count {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b Bag) String() string {
+	values := sets.List(sets.KeySet(b.counts))
+	formatted := make([]string, len(values))
+
+	for i, value := range values {
+		formatted[i] = fmt.Sprintf("%s: %d", strconv.Quote(value), b.counts[value])
+	}
+
+	return "bag{" + strings.Join(formatted, ", ") + "}"
+}
+
+func (b Bag) clone() Bag {
+	counts := make(map[string]int64, len(b.counts))
+	for value, count := range b.counts {
+		counts[value] = count
+	}
+
+	return Bag{counts: counts}
+}
+
+func (b Bag) insert(items ...string) {
+	for _, item := range items {
+		b.counts[item]++
+	}
+}
+
+// combine merges the other bag into this one, using f to determine the new
+// count for each value; values with a count of 0 are removed.
+func (b Bag) combine(other Bag, f func(a, b int64) int64) {
+	for value := range other.counts {
+		if _, exists := b.counts[value]; !exists {
+			b.counts[value] = 0
+		}
+	}
+
+	for value, count := range b.counts {
+		if newCount := f(count, other.counts[value]); newCount > 0 {
+			b.counts[value] = newCount
+		} else {
+			delete(b.counts, value)
+		}
+	}
+}
+
+// DeepCopy implements deepcopy.Copier.
+func (b Bag) DeepCopy() (any, error) {
+	return b.clone(), nil
+}
+
+// CoalesceToString implements coalescing.CustomStringCoalescer.
+func (b Bag) CoalesceToString(_ coalescing.Coalescer) (string, error) {
+	return b.String(), nil
+}
+
+// MarshalJSON implements json.Marshaler. Bags are encoded as objects mapping
+// each value to its count.
+func (b Bag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.counts)
+}
+
+// MarshalYAML implements yaml.Marshaler, see MarshalJSON.
+func (b Bag) MarshalYAML() (any, error) {
+	return b.counts, nil
+}
+
+func toBag(val any) (Bag, error) {
+	b, ok := val.(Bag)
+	if !ok {
+		return Bag{}, fmt.Errorf("not a bag, but %T", val)
+	}
+
+	if b.counts == nil {
+		return NewBag(), nil
+	}
+
+	return b, nil
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBagEntries(t *testing.T) {
+	b := NewBag("b", "a", "c", "b", "a", "b")
+
+	expected := []BagEntry{
+		{Value: "b", Count: 3},
+		{Value: "a", Count: 2},
+		{Value: "c", Count: 1},
+	}
+
+	if entries := b.Entries(); !reflect.DeepEqual(expected, entries) {
+		t.Fatalf("Expected %v, but got %v", expected, entries)
+	}
+
+	if s := b.String(); s != `bag{"a": 2, "b": 3, "c": 1}` {
+		t.Fatalf("Unexpected string representation %s", s)
+	}
+}
+
+func TestBagCombine(t *testing.T) {
+	highest := func(a, b int64) int64 {
+		if a > b {
+			return a
+		}
+		return b
+	}
+
+	lowest := func(a, b int64) int64 {
+		if a < b {
+			return a
+		}
+		return b
+	}
+
+	a := NewBag("x", "x", "y")
+	b := NewBag("x", "y", "y", "z")
+
+	union := a.clone()
+	union.combine(b, highest)
+
+	if expected := NewBag("x", "x", "y", "y", "z"); !union.Equal(expected) {
+		t.Fatalf("Expected union %v, but got %v", expected, union)
+	}
+
+	intersection := a.clone()
+	intersection.combine(b, lowest)
+
+	if expected := NewBag("x", "y"); !intersection.Equal(expected) {
+		t.Fatalf("Expected intersection %v, but got %v", expected, intersection)
+	}
+
+	if !a.Equal(NewBag("x", "x", "y")) {
+		t.Fatalf("Original bag should not have been modified, but is %v now.", a)
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// YAMLTag is the tag used when encoding string sets as YAML. Other kinds of
+// sets use their own tags, like "!int-set" or "!ordered-set", see
+// YAMLDecoders.
+const YAMLTag = "!set"
+
+// YAMLDecoders contains a decoder for each tag used when encoding sets as
+// YAML. Register all of them with the yaml module to turn tagged nodes back
+// into the same kind of set.
+var YAMLDecoders = yamlDecoders()
+
+var (
+	_ json.Marshaler   = Set{}
+	_ yamlv3.Marshaler = Set{}
+)
+
+// MarshalJSON implements json.Marshaler. Sets are encoded as sorted lists.
+func (s Set) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.List())
+}
+
+// MarshalYAML implements yaml.Marshaler. Sets are encoded as sorted lists (or
+// in insertion order for ordered sets), tagged with a tag that describes the
+// kind of set, so that they can be decoded as the same kind of set again.
+func (s Set) MarshalYAML() (any, error) {
+	set := s.set
+	if set == nil {
+		set = New[string]().set
+	}
+
+	node := &yamlv3.Node{
+		Kind: yamlv3.SequenceNode,
+		Tag:  yamlTag(set),
+	}
+
+	for _, item := range set.list() {
+		valueNode, err := encodeYAMLValue(item)
+		if err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content, valueNode)
+	}
+
+	return node, nil
+}
+
+// encodeYAMLValue encodes a single set value. Whole floats are written as
+// "1.0" instead of "1", so that they are not decoded as integers.
+func encodeYAMLValue(value any) (*yamlv3.Node, error) {
+	node := &yamlv3.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+
+	if _, ok := value.(float64); ok && node.Tag == "!!int" {
+		node.Tag = "!!float"
+		node.Value += ".0"
+	}
+
+	return node, nil
+}
+
+// yamlTag returns the tag for a set, e.g. "!set" for string sets,
+// "!ordered-int-set" for ordered int sets and "!normalized-set:casefold+trim"
+// for normalized sets.
+func yamlTag(s typedSet) string {
+	if normalized, ok := s.(*normalizedSet); ok {
+		return normalizedYAMLTag(normalized.normalizer.modes)
+	}
+
+	return typedYAMLTag(s.elementType(), s.ordered())
+}
+
+func typedYAMLTag(kind string, ordered bool) string {
+	tag := "set"
+	if kind != stringElements.name {
+		tag = kind + "-" + tag
+	}
+
+	if ordered {
+		tag = "ordered-" + tag
+	}
+
+	return "!" + tag
+}
+
+func normalizedYAMLTag(modes []string) string {
+	return "!normalized-set:" + strings.Join(modes, "+")
+}
+
+func yamlDecoders() map[string]func(value any) (any, error) {
+	decoders := map[string]func(value any) (any, error){}
+
+	for _, kind := range []string{stringElements.name, intElements.name, floatElements.name, boolElements.name, valueElements.name} {
+		for _, ordered := range []bool{false, true} {
+			decoders[typedYAMLTag(kind, ordered)] = typedSetDecoder(kind, ordered)
+		}
+	}
+
+	// string sets can be decoded from arbitrary lists
+	decoders[YAMLTag] = DecodeSet
+
+	// all combinations of normalization modes, in sorted order
+	allModes := []string{NormalizeCaseFold, NormalizeNFC, NormalizeTrim}
+
+	for mask := 1; mask < 1<<len(allModes); mask++ {
+		modes := []string{}
+		for i, mode := range allModes {
+			if mask&(1<<i) != 0 {
+				modes = append(modes, mode)
+			}
+		}
+
+		decoders[normalizedYAMLTag(modes)] = normalizedSetDecoder(modes)
+	}
+
+	return decoders
+}
+
+// DecodeSet turns a decoded list of scalar values into a set. The element
+// type depends on the values, just like for set-map. It is meant to be used
+// as a hook for decoders, like a tag decoder for the yaml module:
+//
+//	yaml.RegisterTagDecoder(set.YAMLTag, set.DecodeSet)
+//
+// To restore other kinds of sets as well, register all YAMLDecoders instead.
+func DecodeSet(value any) (any, error) {
+	values, err := toYAMLValues(value)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newSetForValues(values, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.add(values...); err != nil {
+		return nil, err
+	}
+
+	return Set{set: s}, nil
+}
+
+func typedSetDecoder(kind string, ordered bool) func(value any) (any, error) {
+	return func(value any) (any, error) {
+		values, err := toYAMLValues(value)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := newSetOfType(kind, ordered)
+		if err != nil {
+			return nil, err
+		}
+
+		// whole floats might have been written as integers by hand
+		if kind == floatElements.name {
+			for i, value := range values {
+				switch v := value.(type) {
+				case int:
+					values[i] = float64(v)
+				case int64:
+					values[i] = float64(v)
+				}
+			}
+		}
+
+		if err := s.add(values...); err != nil {
+			return nil, err
+		}
+
+		return Set{set: s}, nil
+	}
+}
+
+func normalizedSetDecoder(modes []string) func(value any) (any, error) {
+	return func(value any) (any, error) {
+		values, err := toYAMLValues(value)
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := newNormalizer(modes...)
+		if err != nil {
+			return nil, err
+		}
+
+		s := newNormalizedSet(n)
+		if err := s.add(values...); err != nil {
+			return nil, err
+		}
+
+		return Set{set: s}, nil
+	}
+}
+
+func toYAMLValues(value any) ([]any, error) {
+	switch v := value.(type) {
+	case []any:
+		// do not modify the caller's list
+		return append([]any{}, v...), nil
+	case nil:
+		return []any{}, nil
+	default:
+		return nil, fmt.Errorf("cannot decode %T as a set", value)
+	}
+}
@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func TestSetJSONEncoding(t *testing.T) {
+	encoded, err := json.Marshal(map[string]any{
+		"strings": New("b", "a"),
+		"ints":    New[int64](2, 1),
+		"empty":   New[string](),
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+
+	expected := `{"empty":[],"ints":[1,2],"strings":["a","b"]}`
+	if string(encoded) != expected {
+		t.Fatalf("Expected %s, but got %s", expected, string(encoded))
+	}
+}
+
+func TestSetYAMLEncoding(t *testing.T) {
+	normalized, err := NewNormalized([]string{NormalizeTrim, NormalizeCaseFold}, "Foo")
+	if err != nil {
+		t.Fatalf("Failed to create set: %v", err)
+	}
+
+	encoded, err := yamlv3.Marshal(map[string]any{
+		"a": New("b", "a"),
+		"b": New[float64](2, 1.5),
+		"c": NewOrdered("b", "a"),
+		"d": normalized,
+		"e": Set{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+
+	expected := `a: !set
+    - a
+    - b
+b: !float-set
+    - 1.5
+    - 2.0
+c: !ordered-set
+    - b
+    - a
+d: !normalized-set:casefold+trim
+    - Foo
+e: !set []
+`
+	if string(encoded) != expected {
+		t.Fatalf("Expected\n%s\nbut got\n%s", expected, string(encoded))
+	}
+}
+
+func TestSetYAMLDecoding(t *testing.T) {
+	normalized, err := NewNormalized([]string{NormalizeCaseFold}, "Foo", "bar")
+	if err != nil {
+		t.Fatalf("Failed to create set: %v", err)
+	}
+
+	testcases := []struct {
+		name string
+		set  Set
+	}{
+		{
+			name: "strings",
+			set:  New("b", "a"),
+		},
+		{
+			name: "empty ints",
+			set:  New[int64](),
+		},
+		{
+			name: "whole floats",
+			set:  New[float64](1, 2),
+		},
+		{
+			name: "values",
+			set:  NewValues(int64(1), float64(1), "1", true),
+		},
+		{
+			name: "ordered",
+			set:  NewOrdered("b", "c", "a"),
+		},
+		{
+			name: "normalized",
+			set:  normalized,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			encoded, err := yamlv3.Marshal(map[string]any{"set": testcase.set})
+			if err != nil {
+				t.Fatalf("Failed to encode: %v", err)
+			}
+
+			var doc struct {
+				Set yamlv3.Node `yaml:"set"`
+			}
+			if err := yamlv3.Unmarshal(encoded, &doc); err != nil {
+				t.Fatalf("Failed to decode: %v", err)
+			}
+
+			decoder, ok := YAMLDecoders[doc.Set.Tag]
+			if !ok {
+				t.Fatalf("No decoder for tag %q", doc.Set.Tag)
+			}
+
+			// this is what the yaml module does for tagged nodes
+			var value any
+			if err := doc.Set.Decode(&value); err != nil {
+				t.Fatalf("Failed to decode: %v", err)
+			}
+
+			decoded, err := decoder(value)
+			if err != nil {
+				t.Fatalf("Failed to decode set: %v", err)
+			}
+
+			assertSameSet(t, testcase.set, decoded.(Set))
+		})
+	}
+}
+
+// assertSameSet checks that both sets are of the same kind and list the same
+// values in the same order.
+func assertSameSet(t *testing.T, expected Set, actual Set) {
+	t.Helper()
+
+	if expected.set.elementType() != actual.set.elementType() {
+		t.Fatalf("Expected %s set, but got %s set", expected.set.elementType(), actual.set.elementType())
+	}
+
+	if expected.set.ordered() != actual.set.ordered() {
+		t.Fatalf("Expected ordered=%v, but got ordered=%v", expected.set.ordered(), actual.set.ordered())
+	}
+
+	expectedNormalized, _ := expected.set.(*normalizedSet)
+	actualNormalized, _ := actual.set.(*normalizedSet)
+
+	if (expectedNormalized == nil) != (actualNormalized == nil) {
+		t.Fatalf("Expected %T, but got %T", expected.set, actual.set)
+	}
+
+	if expectedNormalized != nil && !reflect.DeepEqual(expectedNormalized.normalizer.modes, actualNormalized.normalizer.modes) {
+		t.Fatalf("Expected modes %v, but got %v", expectedNormalized.normalizer.modes, actualNormalized.normalizer.modes)
+	}
+
+	if !reflect.DeepEqual(expected.List(), actual.List()) {
+		t.Fatalf("Expected %v, but got %v", expected.List(), actual.List())
+	}
+}
+
+func TestDecodeSet(t *testing.T) {
+	testcases := []struct {
+		name     string
+		value    any
+		expected Set
+		invalid  bool
+	}{
+		{
+			name:     "strings",
+			value:    []any{"a", "b", "a"},
+			expected: New("a", "b"),
+		},
+		{
+			name:     "ints",
+			value:    []any{1, 2},
+			expected: New[int64](1, 2),
+		},
+		{
+			name:    "mapping",
+			value:   map[string]any{"a": nil, "b": nil},
+			invalid: true,
+		},
+		{
+			name:     "empty",
+			value:    nil,
+			expected: New[string](),
+		},
+		{
+			name:    "nested",
+			value:   []any{[]any{"a"}},
+			invalid: true,
+		},
+		{
+			name:    "scalar",
+			value:   "a",
+			invalid: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			decoded, err := DecodeSet(testcase.value)
+			if testcase.invalid {
+				if err == nil {
+					t.Fatalf("Expected error, but got %v", decoded)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to decode: %v", err)
+			}
+
+			assertSameSet(t, testcase.expected, decoded.(Set))
+		})
+	}
+}
+
+func TestYAMLDecoders(t *testing.T) {
+	normalized, err := NewNormalized([]string{NormalizeCaseFold}, "Foo")
+	if err != nil {
+		t.Fatalf("Failed to create set: %v", err)
+	}
+
+	testcases := []struct {
+		tag      string
+		value    any
+		expected Set
+		invalid  bool
+	}{
+		{
+			tag:      "!float-set",
+			value:    []any{1, 2.5},
+			expected: New[float64](1, 2.5),
+		},
+		{
+			tag:      "!ordered-int-set",
+			value:    []any{2, 1},
+			expected: NewOrdered[int64](2, 1),
+		},
+		{
+			tag:      "!value-set",
+			value:    nil,
+			expected: NewValues(),
+		},
+		{
+			tag:      "!normalized-set:casefold",
+			value:    []any{"Foo", "foo"},
+			expected: normalized,
+		},
+		{
+			tag:     "!bool-set",
+			value:   []any{"a"},
+			invalid: true,
+		},
+		{
+			tag:     "!ordered-set",
+			value:   map[string]any{"a": nil},
+			invalid: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.tag, func(t *testing.T) {
+			decoder, ok := YAMLDecoders[testcase.tag]
+			if !ok {
+				t.Fatalf("No decoder for tag %q", testcase.tag)
+			}
+
+			decoded, err := decoder(testcase.value)
+			if testcase.invalid {
+				if err == nil {
+					t.Fatalf("Expected error, but got %v", decoded)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to decode: %v", err)
+			}
+
+			assertSameSet(t, testcase.expected, decoded.(Set))
+		})
+	}
+}
@@ -4,226 +4,864 @@
 package set
 
 import (
-	"errors"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"go.xrstf.de/rudi"
+	"go.xrstf.de/rudi/pkg/ast"
 	"go.xrstf.de/rudi/pkg/runtime/types"
 )
 
 var (
 	Functions = rudi.Functions{
-		"new-set":     rudi.NewFunctionBuilder(newEmptySetFunction, newSetFunction).WithDescription("create a set filled with the given values").Build(),
-		"new-key-set": rudi.NewFunctionBuilder(keySetFunction).WithDescription("create a set filled with the keys of an object").Build(),
-
-		"set-delete":       rudi.NewFunctionBuilder(setDeleteFunction).WithDescription("returns a copy of the set with the given values removed from it").Build(),
-		"set-diff":         rudi.NewFunctionBuilder(setDifferenceFunction).WithDescription("returns the difference between two sets").Build(),
-		"set-insert":       rudi.NewFunctionBuilder(setInsertFunction).WithDescription("returns a copy of the set with the newly added values inserted to it").Build(),
-		"set-intersection": rudi.NewFunctionBuilder(setIntersectionFunction).WithDescription("returns the insersection of two sets").Build(),
-		"set-list":         rudi.NewFunctionBuilder(setListFunction).WithDescription("returns a sorted vector containing the values of the set").Build(),
-		"set-size":         rudi.NewFunctionBuilder(setLenFunction).WithDescription("returns the number of values in the set").Build(),
-		"set-symdiff":      rudi.NewFunctionBuilder(setSymmetricDifferenceFunction).WithDescription("returns the symmetric difference between two sets").Build(),
-		"set-union":        rudi.NewFunctionBuilder(setUnionFunction).WithDescription("returns the union of two or more sets").Build(),
-
-		"set-eq?":          rudi.NewFunctionBuilder(setEqualFunction).WithDescription("returns true if two sets hold the same values").Build(),
-		"set-has?":         rudi.NewFunctionBuilder(setHasFunction).WithDescription("returns true if the set contains _all_ of the given values").Build(),
-		"set-has-any?":     rudi.NewFunctionBuilder(setHasAnyFunction).WithDescription("returns true if the set contains _any_ of the given values").Build(),
-		"set-superset-of?": rudi.NewFunctionBuilder(setIsSupersetFunction).WithDescription("returns true if the other set is a superset of the base set").Build(),
+		"new-set":            rudi.NewFunctionBuilder(newEmptySetFunction, newSetFunction).WithDescription("create a set filled with the given values").Build(),
+		"new-key-set":        rudi.NewFunctionBuilder(keySetFunction).WithDescription("create a set filled with the keys of an object").Build(),
+		"new-int-set":        rudi.NewFunctionBuilder(newIntSetFunction).WithDescription("create a set of integers filled with the given values").Build(),
+		"new-float-set":      rudi.NewFunctionBuilder(newFloatSetFunction).WithDescription("create a set of floats filled with the given values").Build(),
+		"new-bool-set":       rudi.NewFunctionBuilder(newBoolSetFunction).WithDescription("create a set of bools filled with the given values").Build(),
+		"new-value-set":      rudi.NewFunctionBuilder(newValueSetFunction).WithDescription("create a set of scalar values of any type filled with the given values").Build(),
+		"new-ordered-set":    rudi.NewFunctionBuilder(newOrderedSetFunction).WithDescription("create a string set that keeps the order in which values were first inserted").Build(),
+		"new-normalized-set": rudi.NewFunctionBuilder(newNormalizedSetFunction).WithDescription("create a string set that compares values after normalizing them (casefold, nfc, trim)").Build(),
+
+		"set-delete":          rudi.NewFunctionBuilder(setDeleteFunction).WithDescription("returns a copy of the set with the given values removed from it").Build(),
+		"set-delete-matching": rudi.NewFunctionBuilder(setDeleteMatchingFunction).WithDescription("returns a copy of a string set without the values matching any of the given glob or regex patterns").Build(),
+		"set-diff":            rudi.NewFunctionBuilder(setDifferenceFunction).WithDescription("returns the difference between the base set and all other sets").Build(),
+		"set-insert":          rudi.NewFunctionBuilder(setInsertFunction).WithDescription("returns a copy of the set with the newly added values inserted to it").Build(),
+		"set-intersection":    rudi.NewFunctionBuilder(setIntersectionFunction).WithDescription("returns the insersection of two or more sets").Build(),
+		"set-list":            rudi.NewFunctionBuilder(setListFunction).WithDescription("returns a vector containing the values of the set in sorted (or insertion, for ordered sets) order").Build(),
+		"set-match":           rudi.NewFunctionBuilder(setMatchFunction).WithDescription("returns the subset of a string set with the values matching any of the given glob or regex patterns").Build(),
+		"set-combinations":    rudi.NewFunctionBuilder(setCombinationsFunction).WithDescription("returns all subsets of a set with exactly k values").Build(),
+		"set-powerset":        rudi.NewFunctionBuilder(setPowersetFunction).WithDescription("returns all subsets of a set").Build(),
+		"set-product":         rudi.NewFunctionBuilder(setProductFunction).WithDescription("returns the cartesian product of one or more sets as a vector of tuples").Build(),
+		"set-partition":       rudi.NewFunctionBuilder(setPartitionFunction).WithDescription("splits the values of two or more sets into the regions of a Venn diagram").Build(),
+		"set-size":            rudi.NewFunctionBuilder(setLenFunction).WithDescription("returns the number of values in the set").Build(),
+		"set-symdiff":         rudi.NewFunctionBuilder(setSymmetricDifferenceFunction).WithDescription("returns the values that occur in an odd number of the given sets").Build(),
+		"set-union":           rudi.NewFunctionBuilder(setUnionFunction).WithDescription("returns the union of two or more sets").Build(),
+
+		"set-eq?":                 rudi.NewFunctionBuilder(setEqualFunction).WithDescription("returns true if all sets hold the same values").Build(),
+		"set-has?":                rudi.NewFunctionBuilder(setHasFunction).WithDescription("returns true if the set contains _all_ of the given values").Build(),
+		"set-has-any?":            rudi.NewFunctionBuilder(setHasAnyFunction).WithDescription("returns true if the set contains _any_ of the given values").Build(),
+		"set-has-match?":          rudi.NewFunctionBuilder(setHasMatchFunction).WithDescription("returns true if any value of a string set matches any of the given glob or regex patterns").Build(),
+		"set-superset-of?":        rudi.NewFunctionBuilder(setIsSupersetFunction).WithDescription("returns true if the base set is a superset of all other sets").Build(),
+		"set-proper-superset-of?": rudi.NewFunctionBuilder(setIsProperSupersetFunction).WithDescription("returns true if the base set is a proper superset of all other sets").Build(),
+		"set-subset-of?":          rudi.NewFunctionBuilder(setIsSubsetFunction).WithDescription("returns true if the base set is a subset of all other sets").Build(),
+		"set-proper-subset-of?":   rudi.NewFunctionBuilder(setIsProperSubsetFunction).WithDescription("returns true if the base set is a proper subset of all other sets").Build(),
+		"set-disjoint?":           rudi.NewFunctionBuilder(setIsDisjointFunction).WithDescription("returns true if no value occurs in more than one of the sets").Build(),
+
+		"new-bag":          rudi.NewFunctionBuilder(newBagFunction).WithDescription("create a bag (multiset) of strings filled with the given values").Build(),
+		"bag-insert":       rudi.NewFunctionBuilder(bagInsertFunction).WithDescription("returns a copy of the bag with the newly added values inserted to it").Build(),
+		"bag-count":        rudi.NewFunctionBuilder(bagCountFunction).WithDescription("returns how often a value occurs in the bag").Build(),
+		"bag-most-common":  rudi.NewFunctionBuilder(bagMostCommonFunction, bagMostCommonLimitFunction).WithDescription("returns the values of a bag together with their counts, most common first").Build(),
+		"bag-union":        rudi.NewFunctionBuilder(bagUnionFunction).WithDescription("returns a bag containing each value with the highest count it has in any of the bags").Build(),
+		"bag-intersection": rudi.NewFunctionBuilder(bagIntersectionFunction).WithDescription("returns a bag containing each value with the lowest count it has in all of the bags").Build(),
+		"bag-to-set":       rudi.NewFunctionBuilder(bagToSetFunction).WithDescription("returns a string set containing the distinct values of a bag").Build(),
+		"set-to-bag":       rudi.NewFunctionBuilder(setToBagFunction).WithDescription("returns a bag containing each value of a string set once").Build(),
+
+		"set-map":    rudi.NewFunctionBuilder(setMapFunction).WithDescription("returns a new set containing the results of applying an expression to each value").Build(),
+		"set-filter": rudi.NewFunctionBuilder(setFilterFunction).WithDescription("returns a new set containing only the values for which an expression returns true").Build(),
+		"set-reduce": rudi.NewFunctionBuilder(setReduceFunction).WithDescription("combines all values of a set into a single value by repeatedly evaluating an expression").Build(),
+		"set-any?":   rudi.NewFunctionBuilder(setAnyFunction).WithDescription("returns true if an expression returns true for at least one value of the set").Build(),
+		"set-all?":   rudi.NewFunctionBuilder(setAllFunction).WithDescription("returns true if an expression returns true for all values of the set").Build(),
 	}
 )
 
 func newEmptySetFunction() (any, error) {
-	return sets.New[string](), nil
+	return New[string](), nil
 }
 
 func newSetFunction(ctx types.Context, vals ...any) (any, error) {
-	return insertMany(ctx, sets.New[string](), vals...)
+	return insertMany(ctx, New[string]().set, vals...)
+}
+
+func newIntSetFunction(ctx types.Context, vals ...any) (any, error) {
+	return insertMany(ctx, New[int64]().set, vals...)
+}
+
+func newFloatSetFunction(ctx types.Context, vals ...any) (any, error) {
+	return insertMany(ctx, New[float64]().set, vals...)
+}
+
+func newBoolSetFunction(ctx types.Context, vals ...any) (any, error) {
+	return insertMany(ctx, New[bool]().set, vals...)
+}
+
+func newValueSetFunction(ctx types.Context, vals ...any) (any, error) {
+	return insertMany(ctx, NewValues().set, vals...)
+}
+
+func newOrderedSetFunction(ctx types.Context, vals ...any) (any, error) {
+	return insertMany(ctx, NewOrdered[string]().set, vals...)
+}
+
+func newNormalizedSetFunction(ctx types.Context, modes any, vals ...any) (any, error) {
+	modeNames, err := toValues(ctx, stringElements.name, stringElements.convert, modes)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	n, err := newNormalizer(modeNames...)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	return insertMany(ctx, newNormalizedSet(n), vals...)
 }
 
 func keySetFunction(val map[string]any) (any, error) {
-	return sets.KeySet[string](val), nil
+	return Set{set: &genericSet[string]{set: sets.KeySet[string](val), elements: stringElements}}, nil
 }
 
 func setInsertFunction(ctx types.Context, target any, newItems ...any) (any, error) {
-	s, ok := target.(sets.Set[string])
-	if !ok {
-		return nil, fmt.Errorf("argument #0: not a set, but %T", target)
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
 	}
 
 	// NB: Insert into a clone of the set; adding inplace happens via bang modifier magic
 	// (i.e. "(set-insert! $myset 1 2 3)")
-	return insertMany(ctx, s.Clone(), newItems...)
+	return insertMany(ctx, s.clone(), newItems...)
 }
 
 func setDeleteFunction(ctx types.Context, target any, itemsToRemove ...any) (any, error) {
-	s, ok := target.(sets.Set[string])
-	if !ok {
-		return nil, fmt.Errorf("argument #0: not a set, but %T", target)
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
 	}
 
-	strs, err := toStrings(ctx, itemsToRemove...)
+	// NB: Remove from a clone of the set; removing inplace happens via bang modifier magic
+	// (i.e. "(set-delete! $myset 1 2 3)")
+	s = s.clone()
+	if err := s.delete(ctx, itemsToRemove...); err != nil {
+		return nil, err
+	}
+
+	return Set{set: s}, nil
+}
+
+// partitionMatching splits the values of a string set into those that match
+// any of the patterns and those that do not.
+func partitionMatching(ctx types.Context, target any, patterns []any) (typedSet, []any, []any, error) {
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	if _, ok := s.(setOf[string]); !ok {
+		return nil, nil, nil, fmt.Errorf("argument #0: expected string set, but got %s set", s.elementType())
+	}
+
+	patternStrings, err := toValues(ctx, stringElements.name, stringElements.convert, patterns...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	m, err := newAnyMatcher(patternStrings)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	matching := []any{}
+	notMatching := []any{}
+
+	for _, value := range s.list() {
+		if m(value.(string)) {
+			matching = append(matching, value)
+		} else {
+			notMatching = append(notMatching, value)
+		}
+	}
+
+	return s, matching, notMatching, nil
+}
+
+func setMatchFunction(ctx types.Context, target any, pattern any, patterns ...any) (any, error) {
+	s, _, notMatching, err := partitionMatching(ctx, target, append([]any{pattern}, patterns...))
+	if err != nil {
+		return nil, err
+	}
+
+	s = s.clone()
+	if err := s.delete(ctx, notMatching...); err != nil {
+		return nil, err
+	}
+
+	return Set{set: s}, nil
+}
+
+func setHasMatchFunction(ctx types.Context, target any, pattern any, patterns ...any) (any, error) {
+	_, matching, _, err := partitionMatching(ctx, target, append([]any{pattern}, patterns...))
+	if err != nil {
+		return nil, err
+	}
+
+	return len(matching) > 0, nil
+}
+
+func setDeleteMatchingFunction(ctx types.Context, target any, pattern any, patterns ...any) (any, error) {
+	s, matching, _, err := partitionMatching(ctx, target, append([]any{pattern}, patterns...))
 	if err != nil {
 		return nil, err
 	}
 
 	// NB: Remove from a clone of the set; removing inplace happens via bang modifier magic
-	// (i.e. "(set-delete! $myset 1 2 3)")
-	return s.Clone().Delete(strs...), nil
+	// (i.e. "(set-delete-matching! $myset "foo-*")")
+	s = s.clone()
+	if err := s.delete(ctx, matching...); err != nil {
+		return nil, err
+	}
+
+	return Set{set: s}, nil
 }
 
 func setLenFunction(target any) (any, error) {
-	s, ok := target.(sets.Set[string])
-	if !ok {
-		return nil, fmt.Errorf("argument #0: not a set, but %T", target)
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
 	}
 
-	return s.Len(), nil
+	return s.size(), nil
 }
 
 func setHasFunction(ctx types.Context, target any, items ...any) (any, error) {
-	s, ok := target.(sets.Set[string])
-	if !ok {
-		return nil, fmt.Errorf("argument #0: not a set, but %T", target)
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
 	}
 
-	strs, err := toStrings(ctx, items...)
+	has, err := s.hasAll(ctx, items...)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.HasAll(strs...), nil
+	return has, nil
 }
 
 func setHasAnyFunction(ctx types.Context, target any, items ...any) (any, error) {
-	s, ok := target.(sets.Set[string])
-	if !ok {
-		return nil, fmt.Errorf("argument #0: not a set, but %T", target)
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
 	}
 
-	strs, err := toStrings(ctx, items...)
+	has, err := s.hasAny(ctx, items...)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.HasAny(strs...), nil
+	return has, nil
 }
 
 func setListFunction(ctx types.Context, target any) (any, error) {
-	s, ok := target.(sets.Set[string])
-	if !ok {
-		return nil, fmt.Errorf("argument #0: not a set, but %T", target)
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
 	}
 
-	strs := sets.List(s)
-	result := make([]any, len(strs))
-	for i, str := range strs {
-		result[i] = str
+	return s.list(), nil
+}
+
+// toTypedSets converts all function arguments into sets.
+func toTypedSets(target any, others []any) ([]typedSet, error) {
+	result := make([]typedSet, 0, len(others)+1)
+
+	for i, arg := range append([]any{target}, others...) {
+		s, err := toTypedSet(arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument #%d: %w", i, err)
+		}
+
+		result = append(result, s)
 	}
 
 	return result, nil
 }
 
-type setsFunc func(a, b sets.Set[string]) (any, error)
+// toComparableSets is like toTypedSets, but normalizes all sets if any of
+// them is normalized, so that combining and comparing them does not depend
+// on the order of the sets.
+func toComparableSets(target any, others []any) ([]typedSet, error) {
+	all, err := toTypedSets(target, others)
+	if err != nil {
+		return nil, err
+	}
 
-func callFuncOnSets(a any, b any, f setsFunc) (any, error) {
-	setA, ok := a.(sets.Set[string])
-	if !ok {
-		return nil, fmt.Errorf("argument #0: not a set, but %T", a)
+	return normalizeAll(all)
+}
+
+type foldFunc func(acc, other typedSet) (typedSet, error)
+
+// foldSets combines the base set with all the other sets, one after another.
+func foldSets(target any, others []any, f foldFunc) (any, error) {
+	all, err := toComparableSets(target, others)
+	if err != nil {
+		return nil, err
 	}
 
-	setB, ok := b.(sets.Set[string])
-	if !ok {
-		return nil, fmt.Errorf("argument #1: not a set, but %T", b)
+	acc := all[0]
+
+	for i, other := range all[1:] {
+		acc, err = f(acc, other)
+		if err != nil {
+			return nil, fmt.Errorf("argument #%d: %w", i+1, err)
+		}
 	}
 
-	return f(setA, setB)
+	return Set{set: acc}, nil
 }
 
-func setEqualFunction(target any, other any) (any, error) {
-	return callFuncOnSets(target, other, func(a, b sets.Set[string]) (any, error) {
-		return a.Equal(b), nil
-	})
+type predicateFunc func(base, other typedSet) (bool, error)
+
+// checkSets returns true if the predicate is true for the base set and each
+// of the other sets.
+func checkSets(target any, others []any, f predicateFunc) (any, error) {
+	all, err := toComparableSets(target, others)
+	if err != nil {
+		return nil, err
+	}
+
+	result := true
+
+	for i, other := range all[1:] {
+		ok, err := f(all[0], other)
+		if err != nil {
+			return nil, fmt.Errorf("argument #%d: %w", i+1, err)
+		}
+
+		// keep checking the remaining sets for type errors
+		result = result && ok
+	}
+
+	return result, nil
+}
+
+func setEqualFunction(target any, others ...any) (any, error) {
+	return checkSets(target, others, typedSet.equal)
+}
+
+func setIsSupersetFunction(target any, others ...any) (any, error) {
+	return checkSets(target, others, typedSet.isSuperset)
+}
+
+func setIsProperSupersetFunction(target any, others ...any) (any, error) {
+	return checkSets(target, others, isProperSuperset)
 }
 
-func setIntersectionFunction(target any, other any) (any, error) {
-	return callFuncOnSets(target, other, func(a, b sets.Set[string]) (any, error) {
-		return a.Intersection(b), nil
+func setIsSubsetFunction(target any, others ...any) (any, error) {
+	return checkSets(target, others, func(base, other typedSet) (bool, error) {
+		return other.isSuperset(base)
 	})
 }
 
-func setDifferenceFunction(target any, other any) (any, error) {
-	return callFuncOnSets(target, other, func(a, b sets.Set[string]) (any, error) {
-		return a.Difference(b), nil
+func setIsProperSubsetFunction(target any, others ...any) (any, error) {
+	return checkSets(target, others, func(base, other typedSet) (bool, error) {
+		return isProperSuperset(other, base)
 	})
 }
 
-func setSymmetricDifferenceFunction(target any, other any) (any, error) {
-	return callFuncOnSets(target, other, func(a, b sets.Set[string]) (any, error) {
-		return a.SymmetricDifference(b), nil
+func isProperSuperset(base, other typedSet) (bool, error) {
+	isSuperset, err := base.isSuperset(other)
+	if err != nil {
+		return false, err
+	}
+
+	return isSuperset && base.size() > other.size(), nil
+}
+
+func setIsDisjointFunction(target any, others ...any) (any, error) {
+	all, err := toComparableSets(target, others)
+	if err != nil {
+		return nil, err
+	}
+
+	// all values seen so far
+	seen := all[0]
+
+	for i, other := range all[1:] {
+		common, err := seen.intersection(other)
+		if err != nil {
+			return nil, fmt.Errorf("argument #%d: %w", i+1, err)
+		}
+
+		if common.size() > 0 {
+			return false, nil
+		}
+
+		if seen, err = seen.union(other); err != nil {
+			return nil, fmt.Errorf("argument #%d: %w", i+1, err)
+		}
+	}
+
+	return true, nil
+}
+
+func setIntersectionFunction(target any, others ...any) (any, error) {
+	return foldSets(target, others, typedSet.intersection)
+}
+
+func setDifferenceFunction(target any, others ...any) (any, error) {
+	return foldSets(target, others, typedSet.difference)
+}
+
+func setSymmetricDifferenceFunction(target any, others ...any) (any, error) {
+	return foldSets(target, others, typedSet.symmetricDifference)
+}
+
+func setUnionFunction(target any, others ...any) (any, error) {
+	return foldSets(target, others, typedSet.union)
+}
+
+// setPartitionFunction splits the values of all sets into the regions of a
+// Venn diagram. Each region is identified by a string like "101", meaning
+// the values in that region are in the first and third set, but not in the
+// second one.
+func setPartitionFunction(target any, others ...any) (any, error) {
+	all, err := toComparableSets(target, others)
+	if err != nil {
+		return nil, err
+	}
+
+	everything := all[0]
+	for i, other := range all[1:] {
+		if everything, err = everything.union(other); err != nil {
+			return nil, fmt.Errorf("argument #%d: %w", i+1, err)
+		}
+	}
+
+	regions := map[string][]any{}
+	membership := make([]byte, len(all))
+
+	for _, value := range everything.list() {
+		for i, s := range all {
+			if s.contains(value) {
+				membership[i] = '1'
+			} else {
+				membership[i] = '0'
+			}
+		}
+
+		key := string(membership)
+		regions[key] = append(regions[key], value)
+	}
+
+	result := map[string]any{}
+	for key, values := range regions {
+		// start with an empty set of the same kind as the base set
+		region, err := everything.difference(everything)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := region.add(values...); err != nil {
+			return nil, err
+		}
+
+		result[key] = Set{set: region}
+	}
+
+	return result, nil
+}
+
+func setProductFunction(target any, others ...any) (any, error) {
+	all, err := toTypedSets(target, others)
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make([][]any, len(all))
+	sizes := make([]int, len(all))
+
+	for i, s := range all {
+		lists[i] = s.list()
+		sizes[i] = s.size()
+	}
+
+	if err := checkGeneratedSize(productSize(sizes)); err != nil {
+		return nil, err
+	}
+
+	tuples := product(lists)
+
+	result := make([]any, len(tuples))
+	for i, tuple := range tuples {
+		result[i] = tuple
+	}
+
+	return result, nil
+}
+
+func setPowersetFunction(target any) (any, error) {
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	items := s.list()
+
+	size := 1
+	for range items {
+		if size *= 2; size > MaxGeneratedSize {
+			break
+		}
+	}
+
+	if err := checkGeneratedSize(size); err != nil {
+		return nil, err
+	}
+
+	// sort subsets by size first
+	all := [][]any{}
+	for k := 0; k <= len(items); k++ {
+		all = append(all, combinations(items, k)...)
+	}
+
+	return subsets(s, all)
+}
+
+func setCombinationsFunction(target any, k int64) (any, error) {
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	if k < 0 {
+		return nil, fmt.Errorf("argument #1: k must not be negative")
+	}
+
+	if k > int64(s.size()) {
+		return []any{}, nil
+	}
+
+	if err := checkGeneratedSize(binomial(s.size(), int(k))); err != nil {
+		return nil, err
+	}
+
+	return subsets(s, combinations(s.list(), int(k)))
+}
+
+func newBagFunction(ctx types.Context, vals ...any) (any, error) {
+	return insertIntoBag(ctx, NewBag(), vals...)
+}
+
+func bagInsertFunction(ctx types.Context, target any, newItems ...any) (any, error) {
+	b, err := toBag(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	// NB: Insert into a clone of the bag; adding inplace happens via bang modifier magic
+	// (i.e. "(bag-insert! $mybag 1 2 3)")
+	return insertIntoBag(ctx, b.clone(), newItems...)
+}
+
+func insertIntoBag(ctx types.Context, b Bag, vals ...any) (any, error) {
+	items, err := toValues(ctx, stringElements.name, stringElements.convert, vals...)
+	if err != nil {
+		return nil, err
+	}
+
+	b.insert(items...)
+
+	return b, nil
+}
+
+func bagCountFunction(target any, value string) (any, error) {
+	b, err := toBag(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	return b.Count(value), nil
+}
+
+func bagMostCommonFunction(target any) (any, error) {
+	return bagMostCommonLimitFunction(target, -1)
+}
+
+func bagMostCommonLimitFunction(target any, limit int64) (any, error) {
+	b, err := toBag(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	entries := b.Entries()
+	if limit >= 0 && int(limit) < len(entries) {
+		entries = entries[:limit]
+	}
+
+	result := make([]any, len(entries))
+	for i, entry := range entries {
+		result[i] = []any{entry.Value, entry.Count}
+	}
+
+	return result, nil
+}
+
+func combineBags(target any, others []any, f func(a, b int64) int64) (any, error) {
+	acc, err := toBag(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	acc = acc.clone()
+
+	for i, other := range others {
+		b, err := toBag(other)
+		if err != nil {
+			return nil, fmt.Errorf("argument #%d: %w", i+1, err)
+		}
+
+		acc.combine(b, f)
+	}
+
+	return acc, nil
+}
+
+func bagUnionFunction(target any, others ...any) (any, error) {
+	return combineBags(target, others, func(a, b int64) int64 {
+		if a > b {
+			return a
+		}
+
+		return b
 	})
 }
 
-func setIsSupersetFunction(target any, other any) (any, error) {
-	return callFuncOnSets(target, other, func(a, b sets.Set[string]) (any, error) {
-		return a.IsSuperset(b), nil
+func bagIntersectionFunction(target any, others ...any) (any, error) {
+	return combineBags(target, others, func(a, b int64) int64 {
+		if a < b {
+			return a
+		}
+
+		return b
 	})
 }
 
-func setUnionFunction(target any, others ...any) (any, error) {
-	acc, ok := target.(sets.Set[string])
+func bagToSetFunction(target any) (any, error) {
+	b, err := toBag(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	return Set{set: &genericSet[string]{set: sets.KeySet(b.counts), elements: stringElements}}, nil
+}
+
+func setToBagFunction(target any) (any, error) {
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	strSet, ok := s.(setOf[string])
 	if !ok {
-		return nil, fmt.Errorf("argument #0: not a set, but %T", target)
+		return nil, fmt.Errorf("argument #0: expected string set, but got %s set", s.elementType())
+	}
+
+	return NewBag(strSet.members().UnsortedList()...), nil
+}
+
+// eachValue evaluates expr once for each value of the set (in sorted order),
+// with the value bound to the variable named by the naming vector.
+func eachValue(ctx types.Context, target any, namingVec ast.Expression, expr ast.Expression, f func(value any, result any) (bool, error)) (typedSet, error) {
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
 	}
 
-	for i, otherSet := range others {
-		toUnionize, ok := otherSet.(sets.Set[string])
-		if !ok {
-			return nil, fmt.Errorf("argument #%d: not a set, but %T", i+1, otherSet)
+	names, err := decodeNamingVector(namingVec, 1)
+	if err != nil {
+		return nil, fmt.Errorf("argument #1: %w", err)
+	}
+
+	for _, value := range s.list() {
+		_, result, err := ctx.Runtime().EvalExpression(ctx.WithVariable(names[0], value), expr)
+		if err != nil {
+			return nil, err
 		}
 
-		acc = acc.Union(toUnionize)
+		more, err := f(value, result)
+		if err != nil {
+			return nil, err
+		}
+
+		if !more {
+			break
+		}
 	}
 
-	return acc, nil
+	return s, nil
 }
 
-func insertMany(ctx types.Context, s sets.Set[string], vals ...any) (any, error) {
-	strs, err := toStrings(ctx, vals...)
+func setMapFunction(ctx types.Context, target any, namingVec ast.Expression, expr ast.Expression) (any, error) {
+	results := []any{}
+
+	s, err := eachValue(ctx, target, namingVec, expr, func(_ any, result any) (bool, error) {
+		results = append(results, result)
+		return true, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	s.Insert(strs...)
+	// mapping an empty set keeps the type of the original set
+	if len(results) == 0 {
+		return Set{set: s.clone()}, nil
+	}
 
-	return s, nil
+	mapped, err := newSetForValues(results, s.ordered())
+	if err != nil {
+		return nil, err
+	}
+
+	return insertMany(ctx, mapped, results...)
+}
+
+func setFilterFunction(ctx types.Context, target any, namingVec ast.Expression, expr ast.Expression) (any, error) {
+	removed := []any{}
+
+	s, err := eachValue(ctx, target, namingVec, expr, func(value any, result any) (bool, error) {
+		keep, err := ctx.Coalesce().ToBool(result)
+		if err != nil {
+			return false, err
+		}
+
+		if !keep {
+			removed = append(removed, value)
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s = s.clone()
+	if err := s.delete(ctx, removed...); err != nil {
+		return nil, err
+	}
+
+	return Set{set: s}, nil
+}
+
+func setAnyFunction(ctx types.Context, target any, namingVec ast.Expression, expr ast.Expression) (any, error) {
+	found := false
+
+	_, err := eachValue(ctx, target, namingVec, expr, func(_ any, result any) (bool, error) {
+		matches, err := ctx.Coalesce().ToBool(result)
+		if err != nil {
+			return false, err
+		}
+
+		found = matches
+
+		return !found, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+func setAllFunction(ctx types.Context, target any, namingVec ast.Expression, expr ast.Expression) (any, error) {
+	all := true
+
+	_, err := eachValue(ctx, target, namingVec, expr, func(_ any, result any) (bool, error) {
+		matches, err := ctx.Coalesce().ToBool(result)
+		if err != nil {
+			return false, err
+		}
+
+		all = matches
+
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
+
+func setReduceFunction(ctx types.Context, target any, initial any, namingVec ast.Expression, expr ast.Expression) (any, error) {
+	s, err := toTypedSet(target)
+	if err != nil {
+		return nil, fmt.Errorf("argument #0: %w", err)
+	}
+
+	names, err := decodeNamingVector(namingVec, 2)
+	if err != nil {
+		return nil, fmt.Errorf("argument #2: %w", err)
+	}
+
+	acc := initial
+
+	for _, value := range s.list() {
+		_, acc, err = ctx.Runtime().EvalExpression(ctx.WithVariable(names[0], acc).WithVariable(names[1], value), expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return acc, nil
+}
+
+// decodeNamingVector extracts the variable names from a naming vector like
+// `[acc value]`, which must contain exactly the given number of variables.
+func decodeNamingVector(expr ast.Expression, size int) ([]string, error) {
+	vec, ok := expr.(ast.VectorNode)
+	if !ok {
+		return nil, fmt.Errorf("expected naming vector, but got %s", expr.ExpressionName())
+	}
+
+	if len(vec.Expressions) != size {
+		return nil, fmt.Errorf("expected naming vector with %d variable(s), but got %d", size, len(vec.Expressions))
+	}
+
+	names := make([]string, size)
+	for i, e := range vec.Expressions {
+		symbol, ok := e.(ast.Symbol)
+		if !ok || !symbol.IsVariable() {
+			return nil, fmt.Errorf("naming vector element #%d is not a variable", i)
+		}
+
+		names[i] = symbol.Variable.Name()
+	}
+
+	return names, nil
+}
+
+func insertMany(ctx types.Context, s typedSet, vals ...any) (any, error) {
+	if err := s.insert(ctx, vals...); err != nil {
+		return nil, err
+	}
+
+	return Set{set: s}, nil
 }
 
-func toStrings(ctx types.Context, vals ...any) ([]string, error) {
-	result := []string{}
+func toValues[T any](ctx types.Context, kind string, convert func(types.Context, any) (T, error), vals ...any) ([]T, error) {
+	result := []T{}
 
 	for _, v := range vals {
 		// This is purposefully not recursive so we do not run into unexpected situations.
 		vec, err := ctx.Coalesce().ToVector(v)
 		if err == nil {
 			for _, v := range vec {
-				str, err := ctx.Coalesce().ToString(v)
+				converted, err := convert(ctx, v)
 				if err != nil {
-					return nil, errors.New("argument vector contains non-string")
+					return nil, fmt.Errorf("argument vector contains non-%s", kind)
 				}
-				result = append(result, str)
+				result = append(result, converted)
 			}
 
 			continue
 		}
 
-		str, err := ctx.Coalesce().ToString(v)
+		converted, err := convert(ctx, v)
 		if err != nil {
-			return nil, fmt.Errorf("argument is neither vector nor string, but %T", v)
+			return nil, fmt.Errorf("argument is neither vector nor %s, but %T", kind, v)
 		}
 
-		result = append(result, str)
+		result = append(result, converted)
 	}
 
 	return result, nil
@@ -6,8 +6,6 @@ package set
 import (
 	"testing"
 
-	"k8s.io/apimachinery/pkg/util/sets"
-
 	"go.xrstf.de/rudi/pkg/builtin"
 	"go.xrstf.de/rudi/pkg/testutil"
 )
@@ -24,27 +22,27 @@ func TestNewSetFunction(t *testing.T) {
 		},
 		{
 			Expression: `(new-set)`,
-			Expected:   sets.New[string](),
+			Expected:   New[string](),
 		},
 		{
 			Expression: `(new-set "a" "b")`,
-			Expected:   sets.New[string]("a", "b"),
+			Expected:   New[string]("a", "b"),
 		},
 		{
 			Expression: `(new-set ["a" "b"])`,
-			Expected:   sets.New[string]("a", "b"),
+			Expected:   New[string]("a", "b"),
 		},
 		{
 			Expression: `(new-set "a" [])`,
-			Expected:   sets.New[string]("a"),
+			Expected:   New[string]("a"),
 		},
 		{
 			Expression: `(new-set "a" "")`,
-			Expected:   sets.New[string]("a", ""),
+			Expected:   New[string]("a", ""),
 		},
 		{
 			Expression: `(new-set "a" ["b" "c" ""])`,
-			Expected:   sets.New[string]("a", "b", "c", ""),
+			Expected:   New[string]("a", "b", "c", ""),
 		},
 		{
 			Expression: `(new-set "a" [["b"]])`,
@@ -53,7 +51,7 @@ func TestNewSetFunction(t *testing.T) {
 		{
 			// do not explode if a value occurs multiple times
 			Expression: `(new-set ["a" "b" "a"])`,
-			Expected:   sets.New[string]("a", "b"),
+			Expected:   New[string]("a", "b"),
 		},
 	}
 
@@ -81,16 +79,16 @@ func TestNewKeySetFunction(t *testing.T) {
 		},
 		{
 			Expression: `(new-key-set {})`,
-			Expected:   sets.New[string](),
+			Expected:   New[string](),
 		},
 		{
 			Expression: `(new-key-set {a "b" c "d"})`,
-			Expected:   sets.New[string]("a", "c"),
+			Expected:   New[string]("a", "c"),
 		},
 		{
 			// do not explode if an object literal contains the same key twice
 			Expression: `(new-key-set {a "b" c "d" c "x"})`,
-			Expected:   sets.New[string]("a", "c"),
+			Expected:   New[string]("a", "c"),
 		},
 	}
 
@@ -106,33 +104,33 @@ func TestSetInsertFunction(t *testing.T) {
 	testcases := []testutil.Testcase{
 		{
 			Expression: `(set-insert (new-set "a" "b") "c")`,
-			Expected:   sets.New[string]("a", "b", "c"),
+			Expected:   New[string]("a", "b", "c"),
 		},
 		{
 			Expression: `(set-insert (new-set "a" "b") ["c" ""] "d" "a")`,
-			Expected:   sets.New[string]("a", "b", "c", "", "d"),
+			Expected:   New[string]("a", "b", "c", "", "d"),
 		},
 
 		// do not modify in-place
 
 		{
 			Expression: `(set! $s (new-set "a" "b")) (set-insert $s "c")`,
-			Expected:   sets.New[string]("a", "b", "c"),
+			Expected:   New[string]("a", "b", "c"),
 		},
 		{
 			Expression: `(set! $s (new-set "a" "b")) (set-insert $s "c") $s`,
-			Expected:   sets.New[string]("a", "b"),
+			Expected:   New[string]("a", "b"),
 		},
 
 		// modify in-place
 
 		{
 			Expression: `(set! $s (new-set "a" "b")) (set-insert! $s "c")`,
-			Expected:   sets.New[string]("a", "b", "c"),
+			Expected:   New[string]("a", "b", "c"),
 		},
 		{
 			Expression: `(set! $s (new-set "a" "b")) (set-insert! $s "c") $s`,
-			Expected:   sets.New[string]("a", "b", "c"),
+			Expected:   New[string]("a", "b", "c"),
 		},
 	}
 
@@ -148,37 +146,37 @@ func TestSetDeleteFunction(t *testing.T) {
 	testcases := []testutil.Testcase{
 		{
 			Expression: `(set-delete (new-set "a" "b") "c")`,
-			Expected:   sets.New[string]("a", "b"),
+			Expected:   New[string]("a", "b"),
 		},
 		{
 			Expression: `(set-delete (new-set "a" "b") "b")`,
-			Expected:   sets.New[string]("a"),
+			Expected:   New[string]("a"),
 		},
 		{
 			Expression: `(set-delete (new-set "a" "b" "") ["c" ""] "d" "a")`,
-			Expected:   sets.New[string]("b"),
+			Expected:   New[string]("b"),
 		},
 
 		// do not modify in-place
 
 		{
 			Expression: `(set! $s (new-set "a" "b")) (set-delete $s "b")`,
-			Expected:   sets.New[string]("a"),
+			Expected:   New[string]("a"),
 		},
 		{
 			Expression: `(set! $s (new-set "a" "b")) (set-delete $s "b") $s`,
-			Expected:   sets.New[string]("a", "b"),
+			Expected:   New[string]("a", "b"),
 		},
 
 		// modify in-place
 
 		{
 			Expression: `(set! $s (new-set "a" "b")) (set-delete! $s "b")`,
-			Expected:   sets.New[string]("a"),
+			Expected:   New[string]("a"),
 		},
 		{
 			Expression: `(set! $s (new-set "a" "b")) (set-delete! $s "b") $s`,
-			Expected:   sets.New[string]("a"),
+			Expected:   New[string]("a"),
 		},
 	}
 
@@ -334,15 +332,688 @@ func TestSetUnionFunction(t *testing.T) {
 		},
 		{
 			Expression: `(set-union (new-set "a" "b") (new-set "c"))`,
-			Expected:   sets.New[string]("a", "b", "c"),
+			Expected:   New[string]("a", "b", "c"),
 		},
 		{
 			Expression: `(set-union (new-set "a" "b") (new-set "a"))`,
-			Expected:   sets.New[string]("a", "b"),
+			Expected:   New[string]("a", "b"),
 		},
 		{
 			Expression: `(set-union (new-set "a" "b") (new-set "a") (new-set "d"))`,
-			Expected:   sets.New[string]("a", "b", "d"),
+			Expected:   New[string]("a", "b", "d"),
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestTypedSetFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(new-int-set "a")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(new-int-set)`,
+			Expected:   New[int64](),
+		},
+		{
+			Expression: `(new-int-set 3 1 [2 3])`,
+			Expected:   New[int64](1, 2, 3),
+		},
+		{
+			Expression: `(set-list (new-int-set 10 9 1))`,
+			Expected:   []any{int64(1), int64(9), int64(10)},
+		},
+		{
+			Expression: `(new-float-set 1.5 2.5)`,
+			Expected:   New[float64](1.5, 2.5),
+		},
+		{
+			Expression: `(new-bool-set true false true)`,
+			Expected:   New[bool](true, false),
+		},
+		{
+			Expression: `(set-list (new-bool-set true false))`,
+			Expected:   []any{false, true},
+		},
+		{
+			Expression: `(new-value-set {a "b"})`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-list (new-value-set "b" 2 true 1.5 "a" 1))`,
+			Expected:   []any{true, int64(1), 1.5, int64(2), "a", "b"},
+		},
+		{
+			Expression: `(set-has? (new-int-set 1 2) 2)`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-has? (new-int-set 1 2) "2")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-has-any? (new-value-set 1 "a") "1" 1)`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-insert (new-int-set 1) 2)`,
+			Expected:   New[int64](1, 2),
+		},
+		{
+			Expression: `(set-delete (new-bool-set true false) true)`,
+			Expected:   New[bool](false),
+		},
+		{
+			Expression: `(set-size (new-value-set 1 1.0 "1"))`,
+			Expected:   3,
+		},
+		{
+			Expression: `(set-union (new-int-set 1) (new-int-set 2))`,
+			Expected:   New[int64](1, 2),
+		},
+		{
+			Expression: `(set-union (new-int-set 1) (new-set "a"))`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-intersection (new-float-set 1.5 2.5) (new-float-set 2.5))`,
+			Expected:   New[float64](2.5),
+		},
+		{
+			Expression: `(set-eq? (new-int-set 1) (new-float-set 1))`,
+			Invalid:    true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestSetBuiltinInterop(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(eq? (new-set "a" "b") (new-set "b" "a"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(eq? (new-set "a") (new-set "a" "b"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(eq? (new-set "a") (new-set "b"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(lt? (new-set "a") (new-set "a" "b"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(gt? (new-set "a") (new-set "a" "b"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(gt? (new-set "a" "b") (new-set "a"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(lt? (new-set "a") (new-set "b"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(gt? (new-set "a") (new-set "b"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(lt? (new-set "a" "b") (new-set "b" "c"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(gt? (new-set "a" "b") (new-set "b" "c"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(eq? (new-set "a") (new-int-set 1))`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(to-string (new-set "b" "a"))`,
+			Expected:   `set{"a", "b"}`,
+		},
+		{
+			Expression: `(to-string (new-int-set 2 1))`,
+			Expected:   `set{1, 2}`,
+		},
+		{
+			Expression: `(len (new-set "a" "b"))`,
+			Expected:   int64(2),
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestSetHigherOrderFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(set-map (new-set "a" "bb" "cc") [v] (len $v))`,
+			Expected:   New[int64](1, 2),
+		},
+		{
+			Expression: `(set-map (new-int-set 1 2) [v] (if (eq? $v 1) "one" $v))`,
+			Expected:   NewValues("one", int64(2)),
+		},
+		{
+			Expression: `(set-map (new-int-set) [v] "x")`,
+			Expected:   New[int64](),
+		},
+		{
+			Expression: `(set-map (new-set "a") [v] [$v])`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-map (new-set "a") v $v)`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-filter (new-set "a" "bb" "cc") [v] (eq? (len $v) 2))`,
+			Expected:   New("bb", "cc"),
+		},
+		{
+			Expression: `(set-filter (new-int-set 1 2) [v] false)`,
+			Expected:   New[int64](),
+		},
+		{
+			Expression: `(set-reduce (new-int-set 1 2 3) 0 [acc v] (+ $acc $v))`,
+			Expected:   int64(6),
+		},
+		{
+			Expression: `(set-reduce (new-set "b" "a") "" [acc v] (concat "" $acc $v))`,
+			Expected:   "ab",
+		},
+		{
+			Expression: `(set-reduce (new-set "a") "" [v] $v)`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-any? (new-int-set 1 2) [v] (eq? $v 2))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-any? (new-int-set) [v] true)`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-all? (new-int-set 1 2) [v] (eq? $v 2))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-all? (new-int-set) [v] false)`,
+			Expected:   true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestBagFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(new-bag "a" ["b" "a"])`,
+			Expected:   NewBag("a", "a", "b"),
+		},
+		{
+			Expression: `(new-bag true)`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(bag-insert (new-bag "a") "a" "b")`,
+			Expected:   NewBag("a", "a", "b"),
+		},
+		{
+			Expression: `(bag-count (new-bag "a" "b" "a") "a")`,
+			Expected:   int64(2),
+		},
+		{
+			Expression: `(bag-count (new-bag "a") "x")`,
+			Expected:   int64(0),
+		},
+		{
+			Expression: `(bag-most-common (new-bag "a" "b" "b" "c"))`,
+			Expected:   []any{[]any{"b", int64(2)}, []any{"a", int64(1)}, []any{"c", int64(1)}},
+		},
+		{
+			Expression: `(bag-most-common (new-bag "a" "b" "b" "c") 1)`,
+			Expected:   []any{[]any{"b", int64(2)}},
+		},
+		{
+			Expression: `(bag-union (new-bag "a" "a") (new-bag "a" "b"))`,
+			Expected:   NewBag("a", "a", "b"),
+		},
+		{
+			Expression: `(bag-intersection (new-bag "a" "a" "c") (new-bag "a" "b"))`,
+			Expected:   NewBag("a"),
+		},
+		{
+			Expression: `(bag-to-set (new-bag "a" "a" "b"))`,
+			Expected:   New("a", "b"),
+		},
+		{
+			Expression: `(set-to-bag (new-set "a" "b"))`,
+			Expected:   NewBag("a", "b"),
+		},
+		{
+			Expression: `(set-to-bag (new-int-set 1))`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(bag-count (new-set "a") "a")`,
+			Invalid:    true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestOrderedSetFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(set-list (new-ordered-set "c" ["a" "c"] "b"))`,
+			Expected:   []any{"c", "a", "b"},
+		},
+		{
+			Expression: `(set-list (set-insert (new-ordered-set "b") "a" "b"))`,
+			Expected:   []any{"b", "a"},
+		},
+		{
+			Expression: `(set-list (set-delete (new-ordered-set "c" "a" "b") "a"))`,
+			Expected:   []any{"c", "b"},
+		},
+		{
+			Expression: `(set-list (set-union (new-ordered-set "b") (new-ordered-set "c" "a")))`,
+			Expected:   []any{"b", "c", "a"},
+		},
+		{
+			Expression: `(set-list (set-filter (new-ordered-set "c" "aa" "b") [v] (eq? (len $v) 1)))`,
+			Expected:   []any{"c", "b"},
+		},
+		{
+			Expression: `(set-list (set-map (new-ordered-set "ccc" "a") [v] (len $v)))`,
+			Expected:   []any{int64(3), int64(1)},
+		},
+		{
+			Expression: `(set-eq? (new-ordered-set "b" "a") (new-set "a" "b"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(to-string (new-ordered-set "b" "a"))`,
+			Expected:   `set{"b", "a"}`,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestSetAlgebraFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(set-intersection (new-set "a" "b" "c") (new-set "b" "c") (new-set "c" "d"))`,
+			Expected:   New("c"),
+		},
+		{
+			Expression: `(set-diff (new-set "a" "b" "c") (new-set "a") (new-set "c"))`,
+			Expected:   New("b"),
+		},
+		{
+			Expression: `(set-symdiff (new-set "a" "b") (new-set "b" "c") (new-set "c" "d"))`,
+			Expected:   New("a", "d"),
+		},
+		{
+			Expression: `(set-symdiff (new-set "a") (new-set "a") (new-set "a"))`,
+			Expected:   New("a"),
+		},
+		{
+			Expression: `(set-diff (new-set "a") (new-set "b") (new-int-set 1))`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-eq? (new-set "a") (new-set "a") (new-set "a"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-eq? (new-set "a") (new-set "a") (new-set "b"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-superset-of? (new-set "a" "b") (new-set "a") (new-set "b"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-superset-of? (new-set "a" "b") (new-set "a") (new-set "c"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-proper-superset-of? (new-set "a" "b") (new-set "a" "b"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-proper-superset-of? (new-set "a" "b") (new-set "a"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-subset-of? (new-set "a") (new-set "a" "b") (new-set "a" "c"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-subset-of? (new-set "a" "b") (new-set "a" "b"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-proper-subset-of? (new-set "a" "b") (new-set "a" "b"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-proper-subset-of? (new-set "a") (new-set "a" "b"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-disjoint? (new-set "a") (new-set "b") (new-set "c"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-disjoint? (new-set "a") (new-set "b") (new-set "c" "a"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-disjoint? (new-set "a") (new-int-set 1))`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-partition (new-set "a" "b") (new-set "b" "c"))`,
+			Expected: map[string]any{
+				"10": New("a"),
+				"11": New("b"),
+				"01": New("c"),
+			},
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestSetPartitionFunction(t *testing.T) {
+	result, err := setPartitionFunction(New("a", "b", "c"), New("b", "c", "d"), New("c", "e"))
+	if err != nil {
+		t.Fatalf("Failed to partition sets: %v", err)
+	}
+
+	expected := map[string]Set{
+		"100": New("a"),
+		"110": New("b"),
+		"111": New("c"),
+		"010": New("d"),
+		"001": New("e"),
+	}
+
+	regions := result.(map[string]any)
+	if len(regions) != len(expected) {
+		t.Fatalf("Expected %d regions, but got %v", len(expected), regions)
+	}
+
+	for key, expectedSet := range expected {
+		region, ok := regions[key].(Set)
+		if !ok || !region.Equal(expectedSet) {
+			t.Errorf("Expected region %s to be %v, but got %v", key, expectedSet, regions[key])
+		}
+	}
+
+	if _, err := setPartitionFunction(New("a"), New[int64](1)); err == nil {
+		t.Fatal("Expected error when partitioning string and int sets, but got none.")
+	}
+}
+
+func TestSetGeneratorFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(set-product (new-set "linux" "darwin") (new-set "arm64" "amd64"))`,
+			Expected: []any{
+				[]any{"darwin", "amd64"},
+				[]any{"darwin", "arm64"},
+				[]any{"linux", "amd64"},
+				[]any{"linux", "arm64"},
+			},
+		},
+		{
+			Expression: `(set-product (new-set "a") (new-int-set 2 1))`,
+			Expected:   []any{[]any{"a", int64(1)}, []any{"a", int64(2)}},
+		},
+		{
+			Expression: `(set-product (new-set "a") (new-set))`,
+			Expected:   []any{},
+		},
+		{
+			Expression: `(set-powerset (new-set "b" "a"))`,
+			Expected:   []any{New[string](), New("a"), New("b"), New("a", "b")},
+		},
+		{
+			Expression: `(set-combinations (new-int-set 3 1 2) 2)`,
+			Expected:   []any{New[int64](1, 2), New[int64](1, 3), New[int64](2, 3)},
+		},
+		{
+			Expression: `(set-combinations (new-set "a") 2)`,
+			Expected:   []any{},
+		},
+		{
+			Expression: `(set-combinations (new-set "a") -1)`,
+			Invalid:    true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestNormalizedSetFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(set-list (new-normalized-set "casefold" "Foo" "foo" "BAR"))`,
+			Expected:   []any{"BAR", "Foo"},
+		},
+		{
+			Expression: `(set-has? (new-normalized-set "casefold" "Content-Type") "content-type")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-has? (new-normalized-set ["casefold" "trim"] "Foo") " FOO ")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-list (set-insert (new-normalized-set "casefold" "Foo") "FOO" "bar"))`,
+			Expected:   []any{"Foo", "bar"},
+		},
+		{
+			Expression: `(set-list (set-delete (new-normalized-set "casefold" "Foo" "bar") "FOO"))`,
+			Expected:   []any{"bar"},
+		},
+		{
+			Expression: `(set-list (set-intersection (new-normalized-set "casefold" "Foo" "bar") (new-set "FOO")))`,
+			Expected:   []any{"Foo"},
+		},
+		{
+			Expression: `(new-normalized-set "uppercase" "Foo")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-list (set-intersection (new-set "FOO") (new-normalized-set "casefold" "foo")))`,
+			Expected:   []any{"FOO"},
+		},
+		{
+			Expression: `(set-list (set-union (new-set "FOO") (new-normalized-set "casefold" "foo")))`,
+			Expected:   []any{"FOO"},
+		},
+		{
+			Expression: `(set-list (set-union (new-set "FOO" "bar") (new-set "BAR") (new-normalized-set "casefold" "foo")))`,
+			Expected:   []any{"FOO", "bar"},
+		},
+		{
+			Expression: `(set-list (set-diff (new-set "FOO" "bar") (new-normalized-set "casefold" "foo")))`,
+			Expected:   []any{"bar"},
+		},
+		{
+			Expression: `(set-list (set-symdiff (new-set "FOO") (new-normalized-set "casefold" "foo" "bar")))`,
+			Expected:   []any{"bar"},
+		},
+		{
+			Expression: `(set-partition (new-set "FOO" "x") (new-normalized-set "casefold" "foo"))`,
+			Expected: map[string]any{
+				"10": New("x"),
+				"11": New("FOO"),
+			},
+		},
+		{
+			Expression: `(set-eq? (new-normalized-set "casefold" "Foo") (new-set "FOO"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-eq? (new-set "FOO") (new-normalized-set "casefold" "Foo"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-eq? (new-set "foo" "FOO") (new-normalized-set "casefold" "Foo"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-eq? (new-normalized-set "casefold" "Foo") (new-set "foo" "FOO"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(eq? (new-set "FOO") (new-normalized-set "casefold" "Foo"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-subset-of? (new-set "FOO") (new-normalized-set "casefold" "Foo" "bar"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-superset-of? (new-normalized-set "casefold" "Foo" "bar") (new-set "FOO"))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-proper-superset-of? (new-set "foo" "FOO") (new-normalized-set "casefold" "Foo"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-disjoint? (new-set "FOO") (new-normalized-set "casefold" "Foo"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-disjoint? (new-normalized-set "casefold" "Foo") (new-set "FOO"))`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-eq? (new-normalized-set "casefold" "Foo") (new-normalized-set "trim" " foo "))`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-eq? (new-normalized-set "trim" " foo ") (new-normalized-set "casefold" "Foo"))`,
+			Expected:   true,
+		},
+	}
+
+	funcs := builtin.SafeFunctions.DeepCopy().Add(Functions)
+
+	for _, testcase := range testcases {
+		testcase.Functions = funcs
+		t.Run(testcase.String(), testcase.Run)
+	}
+}
+
+func TestSetMatchFunctions(t *testing.T) {
+	testcases := []testutil.Testcase{
+		{
+			Expression: `(set-match (new-set "kube-system" "kube-public" "default") "kube-*")`,
+			Expected:   New("kube-system", "kube-public"),
+		},
+		{
+			Expression: `(set-match (new-set "kube-system" "kube-public" "default") "/^de/" "kube-s*")`,
+			Expected:   New("kube-system", "default"),
+		},
+		{
+			Expression: `(set-match (new-set "a") "[")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-match (new-int-set 1) "*")`,
+			Invalid:    true,
+		},
+		{
+			Expression: `(set-has-match? (new-set "kube-system" "default") "kube-*")`,
+			Expected:   true,
+		},
+		{
+			Expression: `(set-has-match? (new-set "default") "/^kube-/")`,
+			Expected:   false,
+		},
+		{
+			Expression: `(set-delete-matching (new-set "kube-system" "kube-public" "default") "kube-*")`,
+			Expected:   New("default"),
+		},
+		{
+			Expression: `(set-list (set-delete-matching (new-ordered-set "c" "kube-a" "a") "kube-*"))`,
+			Expected:   []any{"c", "a"},
+		},
+
+		// do not modify in-place
+
+		{
+			Expression: `(set! $s (new-set "a" "b")) (set-delete-matching $s "a") $s`,
+			Expected:   New("a", "b"),
+		},
+
+		// modify in-place
+
+		{
+			Expression: `(set! $s (new-set "a" "b")) (set-delete-matching! $s "a") $s`,
+			Expected:   New("b"),
 		},
 	}
 
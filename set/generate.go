@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"fmt"
+)
+
+// MaxGeneratedSize is the maximum number of elements that set-product,
+// set-powerset and set-combinations are allowed to generate, to prevent Rudi
+// programs from accidentally exhausting all memory.
+var MaxGeneratedSize = 10000
+
+func checkGeneratedSize(size int) error {
+	if size < 0 || size > MaxGeneratedSize {
+		return fmt.Errorf("result would contain more than %d elements", MaxGeneratedSize)
+	}
+
+	return nil
+}
+
+// productSize returns the number of tuples in the cartesian product of sets
+// of the given sizes, or -1 if it exceeds MaxGeneratedSize.
+func productSize(sizes []int) int {
+	for _, s := range sizes {
+		if s == 0 {
+			return 0
+		}
+	}
+
+	size := 1
+
+	for _, s := range sizes {
+		if size > MaxGeneratedSize/s {
+			return -1
+		}
+
+		size *= s
+	}
+
+	return size
+}
+
+// binomial returns n choose k, or -1 if it exceeds MaxGeneratedSize.
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+
+	if k > n-k {
+		k = n - k
+	}
+
+	result := 1
+	for i := 1; i <= k; i++ {
+		// result * (n-k+i) / i is always an integer
+		next := result * (n - k + i)
+		if next/(n-k+i) != result || next/i > MaxGeneratedSize {
+			return -1
+		}
+
+		result = next / i
+	}
+
+	return result
+}
+
+// product returns all tuples of the cartesian product, in lexicographic order
+// based on the order of the given lists.
+func product(lists [][]any) [][]any {
+	result := [][]any{{}}
+
+	for _, list := range lists {
+		next := make([][]any, 0, len(result)*len(list))
+
+		for _, tuple := range result {
+			for _, item := range list {
+				extended := make([]any, len(tuple), len(tuple)+1)
+				copy(extended, tuple)
+				next = append(next, append(extended, item))
+			}
+		}
+
+		result = next
+	}
+
+	return result
+}
+
+// combinations returns all k-element combinations of the items, in
+// lexicographic order based on the order of the items.
+func combinations(items []any, k int) [][]any {
+	result := [][]any{}
+	if k < 0 || k > len(items) {
+		return result
+	}
+
+	indexes := make([]int, k)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	for {
+		combination := make([]any, k)
+		for i, idx := range indexes {
+			combination[i] = items[idx]
+		}
+		result = append(result, combination)
+
+		// find the rightmost index that can still be incremented
+		i := k - 1
+		for i >= 0 && indexes[i] == len(items)-k+i {
+			i--
+		}
+
+		if i < 0 {
+			return result
+		}
+
+		indexes[i]++
+		for j := i + 1; j < k; j++ {
+			indexes[j] = indexes[j-1] + 1
+		}
+	}
+}
+
+// subsets turns each combination into a set of the same kind as the base set.
+func subsets(base typedSet, combinations [][]any) ([]any, error) {
+	result := make([]any, len(combinations))
+
+	for i, combination := range combinations {
+		s, err := base.difference(base)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.add(combination...); err != nil {
+			return nil, err
+		}
+
+		result[i] = Set{set: s}
+	}
+
+	return result, nil
+}
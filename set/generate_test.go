@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProduct(t *testing.T) {
+	result := product([][]any{{"linux", "windows"}, {"amd64", "arm64"}})
+
+	expected := [][]any{
+		{"linux", "amd64"},
+		{"linux", "arm64"},
+		{"windows", "amd64"},
+		{"windows", "arm64"},
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	testcases := []struct {
+		items    []any
+		k        int
+		expected [][]any
+	}{
+		{
+			items:    []any{"a", "b", "c"},
+			k:        0,
+			expected: [][]any{{}},
+		},
+		{
+			items:    []any{"a", "b", "c"},
+			k:        2,
+			expected: [][]any{{"a", "b"}, {"a", "c"}, {"b", "c"}},
+		},
+		{
+			items:    []any{"a", "b", "c"},
+			k:        3,
+			expected: [][]any{{"a", "b", "c"}},
+		},
+		{
+			items:    []any{"a"},
+			k:        2,
+			expected: [][]any{},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run("", func(t *testing.T) {
+			if result := combinations(testcase.items, testcase.k); !reflect.DeepEqual(testcase.expected, result) {
+				t.Fatalf("Expected %v, but got %v", testcase.expected, result)
+			}
+		})
+	}
+}
+
+func TestGeneratedSizeLimits(t *testing.T) {
+	if size := binomial(5, 2); size != 10 {
+		t.Fatalf("Expected 5 choose 2 to be 10, but got %d", size)
+	}
+
+	if size := binomial(1000, 500); size != -1 {
+		t.Fatalf("Expected 1000 choose 500 to exceed the limit, but got %d", size)
+	}
+
+	if size := productSize([]int{10, 10, 10}); size != 1000 {
+		t.Fatalf("Expected product size 1000, but got %d", size)
+	}
+
+	if size := productSize([]int{1000, 1000, 0}); size != 0 {
+		t.Fatalf("Expected product size 0, but got %d", size)
+	}
+
+	if size := productSize([]int{1000, 1000}); size != -1 {
+		t.Fatalf("Expected product to exceed the limit, but got %d", size)
+	}
+
+	items := []any{}
+	for i := 0; i < 20; i++ {
+		items = append(items, int64(i))
+	}
+
+	s, err := newSetForValues(items, false)
+	if err != nil {
+		t.Fatalf("Failed to create set: %v", err)
+	}
+
+	if err := s.add(items...); err != nil {
+		t.Fatalf("Failed to fill set: %v", err)
+	}
+
+	if _, err := setPowersetFunction(Set{set: s}); err == nil {
+		t.Fatal("Expected error for powerset of 20 values, but got none.")
+	}
+}
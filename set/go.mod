@@ -6,6 +6,8 @@ toolchain go1.21.0
 
 require (
 	go.xrstf.de/rudi v0.5.1
+	golang.org/x/text v0.14.0
+	gopkg.in/yaml.v3 v3.0.1
 	k8s.io/apimachinery v0.29.0
 )
 
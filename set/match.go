@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// matcher returns true if a value matches a pattern.
+type matcher func(value string) bool
+
+// newMatcher compiles a pattern, which is either a regular expression wrapped
+// in slashes (like "/^kube-.+$/") or a glob pattern as supported by
+// path.Match (like "kube-*").
+func newMatcher(pattern string) (matcher, error) {
+	if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		expr, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+		}
+
+		return expr.MatchString, nil
+	}
+
+	// path.Match only reports malformed patterns when it gets to them
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+	}
+
+	return func(value string) bool {
+		matched, _ := path.Match(pattern, value)
+		return matched
+	}, nil
+}
+
+// newAnyMatcher returns a matcher that matches values matching any of the
+// given patterns.
+func newAnyMatcher(patterns []string) (matcher, error) {
+	matchers := make([]matcher, len(patterns))
+
+	for i, pattern := range patterns {
+		m, err := newMatcher(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		matchers[i] = m
+	}
+
+	return func(value string) bool {
+		for _, m := range matchers {
+			if m(value) {
+				return true
+			}
+		}
+
+		return false
+	}, nil
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"testing"
+)
+
+func TestMatcher(t *testing.T) {
+	testcases := []struct {
+		pattern string
+		value   string
+		matches bool
+	}{
+		{
+			pattern: "kube-*",
+			value:   "kube-system",
+			matches: true,
+		},
+		{
+			pattern: "kube-*",
+			value:   "default",
+			matches: false,
+		},
+		{
+			pattern: "kube-?",
+			value:   "kube-ab",
+			matches: false,
+		},
+		{
+			pattern: "/^kube-(system|public)$/",
+			value:   "kube-public",
+			matches: true,
+		},
+		{
+			pattern: "/system/",
+			value:   "kube-system",
+			matches: true,
+		},
+		{
+			pattern: "/",
+			value:   "/",
+			matches: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.pattern, func(t *testing.T) {
+			m, err := newMatcher(testcase.pattern)
+			if err != nil {
+				t.Fatalf("Failed to compile pattern: %v", err)
+			}
+
+			if matches := m(testcase.value); matches != testcase.matches {
+				t.Fatalf("Expected %q to match %q: %v, but got %v", testcase.value, testcase.pattern, testcase.matches, matches)
+			}
+		})
+	}
+}
+
+func TestInvalidMatcher(t *testing.T) {
+	for _, pattern := range []string{"[", "/(/"} {
+		if _, err := newMatcher(pattern); err == nil {
+			t.Errorf("Expected error for pattern %q, but got none.", pattern)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"go.xrstf.de/rudi/pkg/runtime/types"
+)
+
+// Normalization modes for string sets.
+const (
+	NormalizeCaseFold = "casefold"
+	NormalizeNFC      = "nfc"
+	NormalizeTrim     = "trim"
+)
+
+// normalizer turns a value into the key that is used to compare values.
+type normalizer struct {
+	// modes are the sorted normalization modes, needed to encode the set.
+	modes []string
+	apply func(string) string
+}
+
+// newNormalizer combines the given modes. Regardless of the order in which
+// they are given, values are trimmed first, then NFC-normalized and then
+// case-folded.
+func newNormalizer(modes ...string) (*normalizer, error) {
+	enabled := sets.New[string]()
+
+	for _, mode := range modes {
+		switch mode {
+		case NormalizeCaseFold, NormalizeNFC, NormalizeTrim:
+			enabled.Insert(mode)
+		default:
+			return nil, fmt.Errorf("unknown normalization mode %q", mode)
+		}
+	}
+
+	if enabled.Len() == 0 {
+		return nil, fmt.Errorf("no normalization mode given")
+	}
+
+	funcs := []func(string) string{}
+
+	if enabled.Has(NormalizeTrim) {
+		funcs = append(funcs, strings.TrimSpace)
+	}
+
+	if enabled.Has(NormalizeNFC) {
+		funcs = append(funcs, norm.NFC.String)
+	}
+
+	if enabled.Has(NormalizeCaseFold) {
+		// cases.Caser is stateful and must not be shared, but creating one is cheap
+		funcs = append(funcs, func(s string) string {
+			return cases.Fold().String(s)
+		})
+	}
+
+	return &normalizer{
+		modes: sets.List(enabled),
+		apply: func(s string) string {
+			for _, f := range funcs {
+				s = f(s)
+			}
+
+			return s
+		},
+	}, nil
+}
+
+// normalizePair prepares two sets for being compared with each other, see
+// normalizeAll.
+func normalizePair(a, b typedSet) (typedSet, typedSet, error) {
+	normalized, err := normalizeAll([]typedSet{a, b})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return normalized[0], normalized[1], nil
+}
+
+// normalizeAll prepares sets for being combined or compared with each other.
+// If any of them is normalized, all of them are normalized using the modes of
+// all sets, so that the result does not depend on the order of the operands.
+// If not all sets are string sets, they are returned as-is.
+func normalizeAll(all []typedSet) ([]typedSet, error) {
+	modes := []string{}
+
+	for _, s := range all {
+		if s.elementType() != stringElements.name {
+			return all, nil
+		}
+
+		if n, ok := s.(*normalizedSet); ok {
+			modes = append(modes, n.normalizer.modes...)
+		}
+	}
+
+	if len(modes) == 0 {
+		return all, nil
+	}
+
+	n, err := newNormalizer(modes...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]typedSet, len(all))
+	for i, s := range all {
+		result[i] = renormalize(s, n)
+	}
+
+	return result, nil
+}
+
+// renormalize returns a copy of a string set that uses the given normalizer.
+func renormalize(s typedSet, n *normalizer) *normalizedSet {
+	result := newNormalizedSet(n)
+	for _, item := range s.list() {
+		result.append(item.(string))
+	}
+
+	return result
+}
+
+// normalizedSet is a string set that compares values by their normalized
+// form, but remembers the spelling each value was first inserted with.
+type normalizedSet struct {
+	// normalized key => original spelling
+	values     map[string]string
+	normalizer *normalizer
+}
+
+// NewNormalized returns a string set that compares values after normalizing
+// them using the given modes (see NormalizeCaseFold, NormalizeNFC and
+// NormalizeTrim).
+func NewNormalized(modes []string, items ...string) (Set, error) {
+	n, err := newNormalizer(modes...)
+	if err != nil {
+		return Set{}, err
+	}
+
+	s := newNormalizedSet(n)
+	s.append(items...)
+
+	return Set{set: s}, nil
+}
+
+func newNormalizedSet(n *normalizer) *normalizedSet {
+	return &normalizedSet{
+		values:     map[string]string{},
+		normalizer: n,
+	}
+}
+
+func (s *normalizedSet) append(items ...string) {
+	for _, item := range items {
+		key := s.normalizer.apply(item)
+		if _, exists := s.values[key]; !exists {
+			s.values[key] = item
+		}
+	}
+}
+
+func (s *normalizedSet) has(item string) bool {
+	_, exists := s.values[s.normalizer.apply(item)]
+	return exists
+}
+
+// keep returns a new set containing only the items for which the predicate
+// returns true.
+func (s *normalizedSet) keep(predicate func(item string) bool) *normalizedSet {
+	result := newNormalizedSet(s.normalizer)
+	for key, item := range s.values {
+		if predicate(item) {
+			result.values[key] = item
+		}
+	}
+
+	return result
+}
+
+func (s *normalizedSet) members() sets.Set[string] {
+	return sets.New(s.originals()...)
+}
+
+func (s *normalizedSet) originals() []string {
+	items := make([]string, 0, len(s.values))
+	for _, item := range s.values {
+		items = append(items, item)
+	}
+
+	sort.Strings(items)
+
+	return items
+}
+
+func (s *normalizedSet) ordered() bool {
+	return false
+}
+
+func (s *normalizedSet) elementType() string {
+	return stringElements.name
+}
+
+func (s *normalizedSet) size() int {
+	return len(s.values)
+}
+
+func (s *normalizedSet) clone() typedSet {
+	return s.keep(func(string) bool { return true })
+}
+
+func (s *normalizedSet) list() []any {
+	items := s.originals()
+
+	result := make([]any, len(items))
+	for i, item := range items {
+		result[i] = item
+	}
+
+	return result
+}
+
+func (s *normalizedSet) add(vals ...any) error {
+	items, err := scalarsOf[string](s.elementType(), vals)
+	if err != nil {
+		return err
+	}
+
+	s.append(items...)
+
+	return nil
+}
+
+func (s *normalizedSet) convert(ctx types.Context, vals []any) ([]string, error) {
+	return toValues(ctx, stringElements.name, stringElements.convert, vals...)
+}
+
+func (s *normalizedSet) insert(ctx types.Context, vals ...any) error {
+	items, err := s.convert(ctx, vals)
+	if err != nil {
+		return err
+	}
+
+	s.append(items...)
+
+	return nil
+}
+
+func (s *normalizedSet) delete(ctx types.Context, vals ...any) error {
+	items, err := s.convert(ctx, vals)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		delete(s.values, s.normalizer.apply(item))
+	}
+
+	return nil
+}
+
+func (s *normalizedSet) hasAll(ctx types.Context, vals ...any) (bool, error) {
+	items, err := s.convert(ctx, vals)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range items {
+		if !s.has(item) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (s *normalizedSet) hasAny(ctx types.Context, vals ...any) (bool, error) {
+	items, err := s.convert(ctx, vals)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range items {
+		if s.has(item) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *normalizedSet) contains(val any) bool {
+	item, ok := val.(string)
+
+	return ok && s.has(item)
+}
+
+// other normalizes the values of the other set like the values of this set,
+// so that the base set always determines how values are compared.
+func (s *normalizedSet) other(other typedSet) (*normalizedSet, error) {
+	o, ok := other.(setOf[string])
+	if !ok {
+		return nil, incompatibleSetsError(s, other)
+	}
+
+	normalized := newNormalizedSet(s.normalizer)
+	normalized.append(sets.List(o.members())...)
+
+	return normalized, nil
+}
+
+func (s *normalizedSet) union(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.keep(func(string) bool { return true })
+	result.append(o.originals()...)
+
+	return result, nil
+}
+
+func (s *normalizedSet) intersection(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.keep(o.has), nil
+}
+
+func (s *normalizedSet) difference(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.keep(func(item string) bool {
+		return !o.has(item)
+	}), nil
+}
+
+func (s *normalizedSet) symmetricDifference(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.keep(func(item string) bool {
+		return !o.has(item)
+	})
+
+	result.append(o.keep(func(item string) bool {
+		return !s.has(item)
+	}).originals()...)
+
+	return result, nil
+}
+
+func (s *normalizedSet) equal(other typedSet) (bool, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return false, err
+	}
+
+	if s.size() != o.size() {
+		return false, nil
+	}
+
+	for key := range s.values {
+		if _, exists := o.values[key]; !exists {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (s *normalizedSet) isSuperset(other typedSet) (bool, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return false, err
+	}
+
+	for key := range o.values {
+		if _, exists := s.values[key]; !exists {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
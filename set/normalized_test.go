@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizer(t *testing.T) {
+	testcases := []struct {
+		modes    []string
+		input    string
+		expected string
+	}{
+		{
+			modes:    []string{NormalizeCaseFold},
+			input:    "Straße",
+			expected: "strasse",
+		},
+		{
+			modes:    []string{NormalizeNFC},
+			input:    "e\u0301",
+			expected: "\u00e9",
+		},
+		{
+			modes:    []string{NormalizeTrim},
+			input:    "  Foo\t",
+			expected: "Foo",
+		},
+		{
+			modes:    []string{NormalizeCaseFold, NormalizeTrim},
+			input:    "  Foo\t",
+			expected: "foo",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.input, func(t *testing.T) {
+			n, err := newNormalizer(testcase.modes...)
+			if err != nil {
+				t.Fatalf("Failed to create normalizer: %v", err)
+			}
+
+			if result := n.apply(testcase.input); result != testcase.expected {
+				t.Fatalf("Expected %q, but got %q", testcase.expected, result)
+			}
+		})
+	}
+
+	if _, err := newNormalizer("nope"); err == nil {
+		t.Fatal("Expected error for unknown mode, but got none.")
+	}
+
+	if _, err := newNormalizer(); err == nil {
+		t.Fatal("Expected error for missing modes, but got none.")
+	}
+}
+
+func TestNormalizedSet(t *testing.T) {
+	s, err := NewNormalized([]string{NormalizeCaseFold}, "Foo", "BAR", "foo", "bar")
+	if err != nil {
+		t.Fatalf("Failed to create set: %v", err)
+	}
+
+	if expected := []any{"BAR", "Foo"}; !reflect.DeepEqual(expected, s.List()) {
+		t.Fatalf("Expected %v, but got %v", expected, s.List())
+	}
+
+	union, err := s.set.union(New("FOO", "baz").set)
+	if err != nil {
+		t.Fatalf("Failed to combine sets: %v", err)
+	}
+
+	if expected := []any{"BAR", "Foo", "baz"}; !reflect.DeepEqual(expected, union.list()) {
+		t.Fatalf("Expected %v, but got %v", expected, union.list())
+	}
+
+	diff, err := s.set.difference(New("bar").set)
+	if err != nil {
+		t.Fatalf("Failed to combine sets: %v", err)
+	}
+
+	if expected := []any{"Foo"}; !reflect.DeepEqual(expected, diff.list()) {
+		t.Fatalf("Expected %v, but got %v", expected, diff.list())
+	}
+
+	if !s.Equal(New("foo", "bar")) {
+		t.Fatal("Expected normalized set to equal a set with differently spelled values.")
+	}
+
+	if _, err := s.set.union(New[int64](1).set); err == nil {
+		t.Fatal("Expected error when combining string and int sets, but got none.")
+	}
+}
+
+func TestNormalizedSetComparisons(t *testing.T) {
+	casefold, err := NewNormalized([]string{NormalizeCaseFold}, "Foo", "bar")
+	if err != nil {
+		t.Fatalf("Failed to create set: %v", err)
+	}
+
+	trimmed, err := NewNormalized([]string{NormalizeTrim}, " foo ", "BAR")
+	if err != nil {
+		t.Fatalf("Failed to create set: %v", err)
+	}
+
+	testcases := []struct {
+		name     string
+		a        Set
+		b        Set
+		expected int
+		// neither set contains the other, so both orders result in 1
+		unrelated bool
+	}{
+		{
+			name:     "plain set with same spelling",
+			a:        casefold,
+			b:        New("Foo", "bar"),
+			expected: 0,
+		},
+		{
+			name:     "plain set with different spelling",
+			a:        casefold,
+			b:        New("FOO", "BAR"),
+			expected: 0,
+		},
+		{
+			name:     "plain set with values that are equal once normalized",
+			a:        casefold,
+			b:        New("foo", "FOO", "bar"),
+			expected: 0,
+		},
+		{
+			name:     "plain subset",
+			a:        casefold,
+			b:        New("FOO"),
+			expected: 1,
+		},
+		{
+			name:     "ordered superset",
+			a:        casefold,
+			b:        NewOrdered("x", "BAR", "FOO"),
+			expected: -1,
+		},
+		{
+			name:     "different normalization modes",
+			a:        casefold,
+			b:        trimmed,
+			expected: 0,
+		},
+		{
+			name:      "disjoint plain set",
+			a:         casefold,
+			b:         New("x"),
+			expected:  1,
+			unrelated: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			// both operand orders must agree
+			for _, reversed := range []bool{false, true} {
+				a, b, expected := testcase.a, testcase.b, testcase.expected
+				if reversed {
+					a, b = b, a
+
+					if !testcase.unrelated {
+						expected = -expected
+					}
+				}
+
+				result, err := a.Compare(b)
+
+				if err != nil {
+					t.Fatalf("Failed to compare (reversed=%v): %v", reversed, err)
+				}
+
+				if result != expected {
+					t.Fatalf("Expected %d (reversed=%v), but got %d", expected, reversed, result)
+				}
+
+				if equal := a.Equal(b); equal != (expected == 0) {
+					t.Fatalf("Expected Equal to return %v (reversed=%v), but got %v", expected == 0, reversed, equal)
+				}
+			}
+		})
+	}
+}
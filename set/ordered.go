@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"go.xrstf.de/rudi/pkg/runtime/types"
+)
+
+// orderedSet is a set that remembers the order in which its values were
+// first inserted. The order is only relevant for listing the values, two
+// ordered sets with the same values are still equal.
+type orderedSet[T comparable] struct {
+	items    []T
+	index    sets.Set[T]
+	elements *elementType[T]
+}
+
+// NewOrdered returns an insertion-ordered set containing the given items.
+func NewOrdered[T Element](items ...T) Set {
+	return newOrderedGenericSet(items...)
+}
+
+// NewOrderedValues returns an insertion-ordered value set, which can contain
+// a mix of the supported scalar types (see Element).
+func NewOrderedValues(items ...any) Set {
+	return newOrderedGenericSet(items...)
+}
+
+func newOrderedGenericSet[T comparable](items ...T) Set {
+	s := newOrderedSet(newGenericSet[T]().set.(*genericSet[T]).elements)
+	s.append(items...)
+
+	return Set{set: s}
+}
+
+func newOrderedSet[T comparable](elements *elementType[T]) *orderedSet[T] {
+	return &orderedSet[T]{
+		items:    []T{},
+		index:    sets.New[T](),
+		elements: elements,
+	}
+}
+
+func (s *orderedSet[T]) members() sets.Set[T] {
+	return s.index
+}
+
+func (s *orderedSet[T]) ordered() bool {
+	return true
+}
+
+func (s *orderedSet[T]) elementType() string {
+	return s.elements.name
+}
+
+func (s *orderedSet[T]) size() int {
+	return len(s.items)
+}
+
+func (s *orderedSet[T]) clone() typedSet {
+	items := make([]T, len(s.items))
+	copy(items, s.items)
+
+	return &orderedSet[T]{
+		items:    items,
+		index:    s.index.Clone(),
+		elements: s.elements,
+	}
+}
+
+func (s *orderedSet[T]) list() []any {
+	result := make([]any, len(s.items))
+	for i, item := range s.items {
+		result[i] = item
+	}
+
+	return result
+}
+
+func (s *orderedSet[T]) append(items ...T) {
+	for _, item := range items {
+		if !s.index.Has(item) {
+			s.index.Insert(item)
+			s.items = append(s.items, item)
+		}
+	}
+}
+
+// keep returns a new ordered set containing only the items for which the
+// predicate returns true, in their original order.
+func (s *orderedSet[T]) keep(predicate func(item T) bool) *orderedSet[T] {
+	result := newOrderedSet(s.elements)
+	for _, item := range s.items {
+		if predicate(item) {
+			result.append(item)
+		}
+	}
+
+	return result
+}
+
+func (s *orderedSet[T]) add(vals ...any) error {
+	items, err := scalarsOf[T](s.elementType(), vals)
+	if err != nil {
+		return err
+	}
+
+	s.append(items...)
+
+	return nil
+}
+
+func (s *orderedSet[T]) insert(ctx types.Context, vals ...any) error {
+	items, err := toValues(ctx, s.elements.name, s.elements.convert, vals...)
+	if err != nil {
+		return err
+	}
+
+	s.append(items...)
+
+	return nil
+}
+
+func (s *orderedSet[T]) delete(ctx types.Context, vals ...any) error {
+	items, err := toValues(ctx, s.elements.name, s.elements.convert, vals...)
+	if err != nil {
+		return err
+	}
+
+	toDelete := sets.New[T](items...)
+	*s = *s.keep(func(item T) bool {
+		return !toDelete.Has(item)
+	})
+
+	return nil
+}
+
+func (s *orderedSet[T]) hasAll(ctx types.Context, vals ...any) (bool, error) {
+	items, err := toValues(ctx, s.elements.name, s.elements.convert, vals...)
+	if err != nil {
+		return false, err
+	}
+
+	return s.index.HasAll(items...), nil
+}
+
+func (s *orderedSet[T]) hasAny(ctx types.Context, vals ...any) (bool, error) {
+	items, err := toValues(ctx, s.elements.name, s.elements.convert, vals...)
+	if err != nil {
+		return false, err
+	}
+
+	return s.index.HasAny(items...), nil
+}
+
+func (s *orderedSet[T]) contains(val any) bool {
+	item, ok := val.(T)
+
+	return ok && s.index.Has(item)
+}
+
+func (s *orderedSet[T]) other(other typedSet) (setOf[T], error) {
+	o, ok := other.(setOf[T])
+	if !ok {
+		return nil, incompatibleSetsError(s, other)
+	}
+
+	return o, nil
+}
+
+// items of the other set, in their listing order
+func (s *orderedSet[T]) otherItems(other setOf[T]) []T {
+	items := make([]T, 0, other.size())
+	for _, item := range other.list() {
+		items = append(items, item.(T))
+	}
+
+	return items
+}
+
+func (s *orderedSet[T]) union(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.clone().(*orderedSet[T])
+	result.append(s.otherItems(o)...)
+
+	return result, nil
+}
+
+func (s *orderedSet[T]) intersection(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.keep(o.members().Has), nil
+}
+
+func (s *orderedSet[T]) difference(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.keep(func(item T) bool {
+		return !o.members().Has(item)
+	}), nil
+}
+
+func (s *orderedSet[T]) symmetricDifference(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.keep(func(item T) bool {
+		return !o.members().Has(item)
+	})
+
+	for _, item := range s.otherItems(o) {
+		if !s.index.Has(item) {
+			result.append(item)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *orderedSet[T]) equal(other typedSet) (bool, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return false, err
+	}
+
+	return s.index.Equal(o.members()), nil
+}
+
+func (s *orderedSet[T]) isSuperset(other typedSet) (bool, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return false, err
+	}
+
+	return s.index.IsSuperset(o.members()), nil
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderedSetOperations(t *testing.T) {
+	a := NewOrdered("c", "a", "b", "a")
+	b := NewOrdered("d", "b", "e")
+
+	testcases := []struct {
+		name     string
+		op       func(a, b typedSet) (typedSet, error)
+		expected []any
+	}{
+		{
+			name:     "union",
+			op:       typedSet.union,
+			expected: []any{"c", "a", "b", "d", "e"},
+		},
+		{
+			name:     "intersection",
+			op:       typedSet.intersection,
+			expected: []any{"b"},
+		},
+		{
+			name:     "difference",
+			op:       typedSet.difference,
+			expected: []any{"c", "a"},
+		},
+		{
+			name:     "symmetricDifference",
+			op:       typedSet.symmetricDifference,
+			expected: []any{"c", "a", "d", "e"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			result, err := testcase.op(a.set, b.set)
+			if err != nil {
+				t.Fatalf("Operation failed: %v", err)
+			}
+
+			if list := result.list(); !reflect.DeepEqual(testcase.expected, list) {
+				t.Fatalf("Expected %v, but got %v", testcase.expected, list)
+			}
+		})
+	}
+
+	if list := a.List(); !reflect.DeepEqual([]any{"c", "a", "b"}, list) {
+		t.Fatalf("Original set should not have been modified, but is %v now.", list)
+	}
+}
+
+func TestOrderedSetWithUnorderedSet(t *testing.T) {
+	ordered := NewOrdered("b", "a")
+	unordered := New("z", "a", "c")
+
+	union, err := ordered.set.union(unordered.set)
+	if err != nil {
+		t.Fatalf("Failed to combine sets: %v", err)
+	}
+
+	if expected := []any{"b", "a", "c", "z"}; !reflect.DeepEqual(expected, union.list()) {
+		t.Fatalf("Expected %v, but got %v", expected, union.list())
+	}
+
+	if !ordered.Equal(New("a", "b")) {
+		t.Fatal("Expected ordered and unordered sets with the same values to be equal.")
+	}
+
+	if _, err := ordered.set.union(New[int64](1).set); err == nil {
+		t.Fatal("Expected error when combining string and int sets, but got none.")
+	}
+}
+
+func TestOrderedValueSet(t *testing.T) {
+	s := NewOrderedValues("b", int64(1), true, "b")
+
+	if expected := []any{"b", int64(1), true}; !reflect.DeepEqual(expected, s.List()) {
+		t.Fatalf("Expected %v, but got %v", expected, s.List())
+	}
+
+	if !s.Equal(NewValues(true, "b", int64(1))) {
+		t.Fatal("Expected ordered and unordered value sets with the same values to be equal.")
+	}
+}
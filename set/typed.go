@@ -0,0 +1,444 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"go.xrstf.de/rudi/pkg/runtime/types"
+)
+
+// typedSet allows the set functions to work with sets of different element
+// types without knowing about the concrete type.
+type typedSet interface {
+	elementType() string
+	// ordered returns true if the set lists its values in insertion order
+	// instead of sorting them.
+	ordered() bool
+	size() int
+	clone() typedSet
+	// list returns the elements in sorted order, or in insertion order for
+	// ordered sets.
+	list() []any
+
+	// add inserts scalar values without coalescing them.
+	add(vals ...any) error
+	insert(ctx types.Context, vals ...any) error
+	delete(ctx types.Context, vals ...any) error
+	hasAll(ctx types.Context, vals ...any) (bool, error)
+	hasAny(ctx types.Context, vals ...any) (bool, error)
+	// contains checks for a value that is already a set element, without
+	// coalescing it.
+	contains(val any) bool
+
+	union(other typedSet) (typedSet, error)
+	intersection(other typedSet) (typedSet, error)
+	difference(other typedSet) (typedSet, error)
+	symmetricDifference(other typedSet) (typedSet, error)
+	equal(other typedSet) (bool, error)
+	isSuperset(other typedSet) (bool, error)
+}
+
+// setOf is implemented by all sets with elements of type T, which allows
+// combining ordered and unordered sets.
+type setOf[T comparable] interface {
+	typedSet
+	members() sets.Set[T]
+}
+
+// elementType describes how Rudi values are converted into set elements and
+// how those are sorted.
+type elementType[T comparable] struct {
+	name    string
+	convert func(ctx types.Context, val any) (T, error)
+	less    func(a, b T) bool
+}
+
+var (
+	stringElements = &elementType[string]{
+		name: "string",
+		convert: func(ctx types.Context, val any) (string, error) {
+			return ctx.Coalesce().ToString(val)
+		},
+		less: func(a, b string) bool { return a < b },
+	}
+
+	intElements = &elementType[int64]{
+		name: "int",
+		convert: func(ctx types.Context, val any) (int64, error) {
+			return ctx.Coalesce().ToInt64(val)
+		},
+		less: func(a, b int64) bool { return a < b },
+	}
+
+	floatElements = &elementType[float64]{
+		name: "float",
+		convert: func(ctx types.Context, val any) (float64, error) {
+			return ctx.Coalesce().ToFloat64(val)
+		},
+		less: func(a, b float64) bool { return a < b },
+	}
+
+	boolElements = &elementType[bool]{
+		name: "bool",
+		convert: func(ctx types.Context, val any) (bool, error) {
+			return ctx.Coalesce().ToBool(val)
+		},
+		less: func(a, b bool) bool { return !a && b },
+	}
+
+	valueElements = &elementType[any]{
+		name:    "value",
+		convert: toScalar,
+		less:    lessScalar,
+	}
+)
+
+// toTypedSet unwraps a Set or wraps any of the supported raw set types.
+func toTypedSet(val any) (typedSet, error) {
+	switch s := val.(type) {
+	case Set:
+		if s.set == nil {
+			return New[string]().set, nil
+		}
+
+		return s.set, nil
+	case sets.Set[string]:
+		return &genericSet[string]{set: s, elements: stringElements}, nil
+	case sets.Set[int64]:
+		return &genericSet[int64]{set: s, elements: intElements}, nil
+	case sets.Set[float64]:
+		return &genericSet[float64]{set: s, elements: floatElements}, nil
+	case sets.Set[bool]:
+		return &genericSet[bool]{set: s, elements: boolElements}, nil
+	case sets.Set[any]:
+		return &genericSet[any]{set: s, elements: valueElements}, nil
+	default:
+		return nil, fmt.Errorf("not a set, but %T", val)
+	}
+}
+
+// newSetForValues returns an empty set whose element type fits all of the
+// given values; values of different types result in a value set.
+func newSetForValues(vals []any, ordered bool) (typedSet, error) {
+	kind := ""
+
+	for _, val := range vals {
+		scalar, err := toScalar(nil, val)
+		if err != nil {
+			return nil, err
+		}
+
+		var valueKind string
+		switch scalar.(type) {
+		case string:
+			valueKind = stringElements.name
+		case int64:
+			valueKind = intElements.name
+		case float64:
+			valueKind = floatElements.name
+		case bool:
+			valueKind = boolElements.name
+		}
+
+		if kind != "" && kind != valueKind {
+			kind = valueElements.name
+			break
+		}
+
+		kind = valueKind
+	}
+
+	if kind == "" {
+		kind = stringElements.name
+	}
+
+	return newSetOfType(kind, ordered)
+}
+
+// newSetOfType returns an empty set for the given element type name.
+func newSetOfType(kind string, ordered bool) (typedSet, error) {
+	var s Set
+
+	switch kind {
+	case stringElements.name:
+		s = newSet[string](ordered)
+	case intElements.name:
+		s = newSet[int64](ordered)
+	case floatElements.name:
+		s = newSet[float64](ordered)
+	case boolElements.name:
+		s = newSet[bool](ordered)
+	case valueElements.name:
+		s = newSet[any](ordered)
+	default:
+		return nil, fmt.Errorf("unknown set type %q", kind)
+	}
+
+	return s.set, nil
+}
+
+func newSet[T comparable](ordered bool) Set {
+	if ordered {
+		return newOrderedGenericSet[T]()
+	}
+
+	return newGenericSet[T]()
+}
+
+func incompatibleSetsError(s typedSet, other typedSet) error {
+	return fmt.Errorf("cannot combine %s set with %s set", s.elementType(), other.elementType())
+}
+
+// scalarsOf converts scalar values into set elements without coalescing them.
+func scalarsOf[T comparable](kind string, vals []any) ([]T, error) {
+	items := make([]T, len(vals))
+
+	for i, val := range vals {
+		scalar, err := toScalar(nil, val)
+		if err != nil {
+			return nil, err
+		}
+
+		item, ok := scalar.(T)
+		if !ok {
+			return nil, fmt.Errorf("cannot add %T to %s set", val, kind)
+		}
+
+		items[i] = item
+	}
+
+	return items, nil
+}
+
+type genericSet[T comparable] struct {
+	set      sets.Set[T]
+	elements *elementType[T]
+}
+
+func (s *genericSet[T]) members() sets.Set[T] {
+	return s.set
+}
+
+func (s *genericSet[T]) ordered() bool {
+	return false
+}
+
+func (s *genericSet[T]) elementType() string {
+	return s.elements.name
+}
+
+func (s *genericSet[T]) size() int {
+	return s.set.Len()
+}
+
+func (s *genericSet[T]) clone() typedSet {
+	return s.wrap(s.set.Clone())
+}
+
+func (s *genericSet[T]) wrap(set sets.Set[T]) typedSet {
+	return &genericSet[T]{set: set, elements: s.elements}
+}
+
+func (s *genericSet[T]) list() []any {
+	items := s.set.UnsortedList()
+	sort.Slice(items, func(i, j int) bool {
+		return s.elements.less(items[i], items[j])
+	})
+
+	result := make([]any, len(items))
+	for i, item := range items {
+		result[i] = item
+	}
+
+	return result
+}
+
+func (s *genericSet[T]) convert(ctx types.Context, vals []any) ([]T, error) {
+	return toValues(ctx, s.elements.name, s.elements.convert, vals...)
+}
+
+func (s *genericSet[T]) insert(ctx types.Context, vals ...any) error {
+	items, err := s.convert(ctx, vals)
+	if err != nil {
+		return err
+	}
+
+	s.set.Insert(items...)
+
+	return nil
+}
+
+func (s *genericSet[T]) add(vals ...any) error {
+	items, err := scalarsOf[T](s.elementType(), vals)
+	if err != nil {
+		return err
+	}
+
+	s.set.Insert(items...)
+
+	return nil
+}
+
+func (s *genericSet[T]) delete(ctx types.Context, vals ...any) error {
+	items, err := s.convert(ctx, vals)
+	if err != nil {
+		return err
+	}
+
+	s.set.Delete(items...)
+
+	return nil
+}
+
+func (s *genericSet[T]) hasAll(ctx types.Context, vals ...any) (bool, error) {
+	items, err := s.convert(ctx, vals)
+	if err != nil {
+		return false, err
+	}
+
+	return s.set.HasAll(items...), nil
+}
+
+func (s *genericSet[T]) hasAny(ctx types.Context, vals ...any) (bool, error) {
+	items, err := s.convert(ctx, vals)
+	if err != nil {
+		return false, err
+	}
+
+	return s.set.HasAny(items...), nil
+}
+
+func (s *genericSet[T]) contains(val any) bool {
+	item, ok := val.(T)
+
+	return ok && s.set.Has(item)
+}
+
+func (s *genericSet[T]) other(other typedSet) (sets.Set[T], error) {
+	o, ok := other.(setOf[T])
+	if !ok {
+		return nil, incompatibleSetsError(s, other)
+	}
+
+	return o.members(), nil
+}
+
+func (s *genericSet[T]) union(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.wrap(s.set.Union(o)), nil
+}
+
+func (s *genericSet[T]) intersection(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.wrap(s.set.Intersection(o)), nil
+}
+
+func (s *genericSet[T]) difference(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.wrap(s.set.Difference(o)), nil
+}
+
+func (s *genericSet[T]) symmetricDifference(other typedSet) (typedSet, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.wrap(s.set.SymmetricDifference(o)), nil
+}
+
+func (s *genericSet[T]) equal(other typedSet) (bool, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return false, err
+	}
+
+	return s.set.Equal(o), nil
+}
+
+func (s *genericSet[T]) isSuperset(other typedSet) (bool, error) {
+	o, err := s.other(other)
+	if err != nil {
+		return false, err
+	}
+
+	return s.set.IsSuperset(o), nil
+}
+
+// toScalar accepts the scalar values that can be stored in a value set. The
+// dynamic type is part of the identity of each value, so 1 and 1.0 are
+// different values.
+func toScalar(_ types.Context, val any) (any, error) {
+	switch v := val.(type) {
+	case string, bool, int64, float64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	default:
+		return nil, fmt.Errorf("%T is not a scalar value", val)
+	}
+}
+
+// lessScalar sorts bools before numbers before strings.
+func lessScalar(a, b any) bool {
+	rankA, rankB := scalarRank(a), scalarRank(b)
+	if rankA != rankB {
+		return rankA < rankB
+	}
+
+	switch va := a.(type) {
+	case bool:
+		return boolElements.less(va, b.(bool))
+	case string:
+		return va < b.(string)
+	default:
+		fa, fb := toFloat(a), toFloat(b)
+		if fa != fb {
+			return fa < fb
+		}
+
+		// sort ints before floats of the same value
+		_, isInt := a.(int64)
+		return isInt
+	}
+}
+
+func scalarRank(val any) int {
+	switch val.(type) {
+	case bool:
+		return 0
+	case int64, float64:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func toFloat(val any) float64 {
+	if i, ok := val.(int64); ok {
+		return float64(i)
+	}
+
+	return val.(float64)
+}
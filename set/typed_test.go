@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestTypedSetList(t *testing.T) {
+	testcases := []struct {
+		name     string
+		set      any
+		expected []any
+	}{
+		{
+			name:     "strings",
+			set:      sets.New[string]("b", "a", "c"),
+			expected: []any{"a", "b", "c"},
+		},
+		{
+			name:     "ints",
+			set:      sets.New[int64](10, 9, -1),
+			expected: []any{int64(-1), int64(9), int64(10)},
+		},
+		{
+			name:     "bools",
+			set:      sets.New[bool](true, false),
+			expected: []any{false, true},
+		},
+		{
+			name:     "values",
+			set:      sets.New[any]("b", int64(2), true, 1.5, "a", int64(1), 1.0, false),
+			expected: []any{false, true, int64(1), 1.0, 1.5, int64(2), "a", "b"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			s, err := toTypedSet(testcase.set)
+			if err != nil {
+				t.Fatalf("Failed to wrap set: %v", err)
+			}
+
+			if list := s.list(); !reflect.DeepEqual(testcase.expected, list) {
+				t.Fatalf("Expected %v, but got %v", testcase.expected, list)
+			}
+		})
+	}
+}
+
+func TestTypedSetIncompatibleTypes(t *testing.T) {
+	a, _ := toTypedSet(sets.New[int64](1))
+	b, _ := toTypedSet(sets.New[float64](1))
+
+	if _, err := a.union(b); err == nil {
+		t.Fatal("Expected error when combining int and float sets, but got none.")
+	}
+}
+
+func TestNewSetForValues(t *testing.T) {
+	testcases := []struct {
+		values   []any
+		expected string
+	}{
+		{
+			values:   []any{"a", "b"},
+			expected: "string",
+		},
+		{
+			values:   []any{int64(1), 2},
+			expected: "int",
+		},
+		{
+			values:   []any{1.5},
+			expected: "float",
+		},
+		{
+			values:   []any{true},
+			expected: "bool",
+		},
+		{
+			values:   []any{"a", int64(1)},
+			expected: "value",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.expected, func(t *testing.T) {
+			s, err := newSetForValues(testcase.values, false)
+			if err != nil {
+				t.Fatalf("Failed to create set: %v", err)
+			}
+
+			if s.elementType() != testcase.expected {
+				t.Fatalf("Expected %s set, but got %s set", testcase.expected, s.elementType())
+			}
+		})
+	}
+
+	if _, err := newSetForValues([]any{[]any{"a"}}, false); err == nil {
+		t.Fatal("Expected error for non-scalar value, but got none.")
+	}
+}
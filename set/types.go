@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"go.xrstf.de/rudi/pkg/coalescing"
+	"go.xrstf.de/rudi/pkg/deepcopy"
+	"go.xrstf.de/rudi/pkg/equality"
+)
+
+// Set is the value type used for all sets in Rudi programs. It wraps one of the
+// supported sets.Set[T] types (string, int64, float64, bool and any).
+type Set struct {
+	set typedSet
+}
+
+var (
+	_ deepcopy.Copier                  = Set{}
+	_ coalescing.CustomStringCoalescer = Set{}
+	_ coalescing.CustomVectorCoalescer = Set{}
+	_ equality.Comparer                = Set{}
+)
+
+// Element is the constraint for the element types of sets that can be
+// created using New and NewOrdered. Use NewValues and NewOrderedValues for
+// sets with mixed element types.
+type Element interface {
+	string | int64 | float64 | bool
+}
+
+// New returns a set containing the given items.
+func New[T Element](items ...T) Set {
+	return newGenericSet(items...)
+}
+
+// NewValues returns a value set, which can contain a mix of the supported
+// scalar types (see Element).
+func NewValues(items ...any) Set {
+	return newGenericSet(items...)
+}
+
+// newGenericSet must only be called for Element types and any, which are
+// guaranteed by its callers.
+func newGenericSet[T comparable](items ...T) Set {
+	s, err := toTypedSet(sets.New[T](items...))
+	if err != nil {
+		panic(err)
+	}
+
+	return Set{set: s}
+}
+
+func (s Set) Len() int {
+	if s.set == nil {
+		return 0
+	}
+
+	return s.set.size()
+}
+
+// List returns the items of the set in sorted order, or in insertion order
+// for ordered sets.
+func (s Set) List() []any {
+	if s.set == nil {
+		return []any{}
+	}
+
+	return s.set.list()
+}
+
+// Equal returns true if both sets have the same element type and contain the
+// same items. This also makes sets comparable using go-cmp.
+func (s Set) Equal(other Set) bool {
+	if s.set == nil || other.set == nil {
+		return s.Len() == other.Len()
+	}
+
+	a, b, err := normalizePair(s.set, other.set)
+	if err != nil {
+		return false
+	}
+
+	equal, err := a.equal(b)
+
+	return err == nil && equal
+}
+
+func (s Set) String() string {
+	items := s.List()
+	formatted := make([]string, len(items))
+
+	for i, item := range items {
+		if str, ok := item.(string); ok {
+			formatted[i] = strconv.Quote(str)
+		} else {
+			formatted[i] = fmt.Sprintf("%v", item)
+		}
+	}
+
+	return "set{" + strings.Join(formatted, ", ") + "}"
+}
+
+// DeepCopy implements deepcopy.Copier.
+func (s Set) DeepCopy() (any, error) {
+	if s.set == nil {
+		return s, nil
+	}
+
+	return Set{set: s.set.clone()}, nil
+}
+
+// CoalesceToString implements coalescing.CustomStringCoalescer.
+func (s Set) CoalesceToString(_ coalescing.Coalescer) (string, error) {
+	return s.String(), nil
+}
+
+// CoalesceToVector implements coalescing.CustomVectorCoalescer.
+func (s Set) CoalesceToVector(_ coalescing.Coalescer) ([]any, error) {
+	return s.List(), nil
+}
+
+// Compare implements equality.Comparer. Sets are only partially ordered, so
+// this returns 0 for equal sets, -1 if the set is a proper subset of the other
+// set and 1 otherwise, i.e. if it is a proper superset or if neither set
+// contains the other. This way eq? works for all sets of the same type, while
+// lt? only means "proper subset".
+func (s Set) Compare(other any) (int, error) {
+	otherSet, ok := other.(Set)
+	if !ok || s.set == nil || otherSet.set == nil {
+		return 0, equality.ErrIncompatibleTypes
+	}
+
+	if s.set.elementType() != otherSet.set.elementType() {
+		return 0, equality.ErrIncompatibleTypes
+	}
+
+	a, b, err := normalizePair(s.set, otherSet.set)
+	if err != nil {
+		return 0, err
+	}
+
+	equal, err := a.equal(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if equal {
+		return 0, nil
+	}
+
+	isSubset, err := b.isSuperset(a)
+	if err != nil {
+		return 0, err
+	}
+
+	if isSubset {
+		return -1, nil
+	}
+
+	return 1, nil
+}
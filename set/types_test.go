@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package set
+
+import (
+	"errors"
+	"testing"
+
+	"go.xrstf.de/rudi/pkg/equality"
+)
+
+func TestSetDeepCopy(t *testing.T) {
+	original := New("a", "b")
+
+	copied, err := original.DeepCopy()
+	if err != nil {
+		t.Fatalf("Failed to copy set: %v", err)
+	}
+
+	copiedSet := copied.(Set)
+	if !copiedSet.Equal(original) {
+		t.Fatalf("Expected copy to equal %v, but got %v", original, copiedSet)
+	}
+
+	copiedSet.set.(*genericSet[string]).set.Insert("c")
+
+	if original.Len() != 2 {
+		t.Fatalf("Original set should not have been modified, but is %v now.", original)
+	}
+}
+
+func TestSetString(t *testing.T) {
+	testcases := []struct {
+		set      Set
+		expected string
+	}{
+		{
+			set:      New[string](),
+			expected: `set{}`,
+		},
+		{
+			set:      New("b", "a", `"`),
+			expected: `set{"\"", "a", "b"}`,
+		},
+		{
+			set:      New[int64](3, 1, 2),
+			expected: `set{1, 2, 3}`,
+		},
+		{
+			set:      NewValues("a", int64(1), true),
+			expected: `set{true, 1, "a"}`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.expected, func(t *testing.T) {
+			if s := testcase.set.String(); s != testcase.expected {
+				t.Fatalf("Expected %s, but got %s", testcase.expected, s)
+			}
+		})
+	}
+}
+
+func TestSetCompare(t *testing.T) {
+	testcases := []struct {
+		a        Set
+		b        any
+		expected int
+		invalid  bool
+	}{
+		{
+			a:        New("a", "b"),
+			b:        New("b", "a"),
+			expected: 0,
+		},
+		{
+			a:        New("a"),
+			b:        New("a", "b"),
+			expected: -1,
+		},
+		{
+			a:        New("a", "b"),
+			b:        New("a"),
+			expected: 1,
+		},
+		{
+			a:        New("a"),
+			b:        New("b"),
+			expected: 1,
+		},
+		{
+			a:        New("b"),
+			b:        New("a"),
+			expected: 1,
+		},
+		{
+			a:        New("a", "b"),
+			b:        New("b", "c"),
+			expected: 1,
+		},
+		{
+			a:        New[int64](),
+			b:        New[int64](1),
+			expected: -1,
+		},
+		{
+			a:       New("a"),
+			b:       New[int64](1),
+			invalid: true,
+		},
+		{
+			a:       New("a"),
+			b:       "a",
+			invalid: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run("", func(t *testing.T) {
+			result, err := testcase.a.Compare(testcase.b)
+			if testcase.invalid {
+				if !errors.Is(err, equality.ErrIncompatibleTypes) {
+					t.Fatalf("Expected ErrIncompatibleTypes, but got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to compare: %v", err)
+			}
+
+			if result != testcase.expected {
+				t.Fatalf("Expected %d, but got %d", testcase.expected, result)
+			}
+		})
+	}
+}
@@ -4,6 +4,9 @@
 package yaml
 
 import (
+	"fmt"
+	"sync"
+
 	yamlv3 "gopkg.in/yaml.v3"
 
 	"go.xrstf.de/rudi"
@@ -16,6 +19,36 @@ var (
 	}
 )
 
+// TagDecoder turns the value of a node with a custom tag (like "!semver") into
+// a custom type. The value is what the node would have been decoded into
+// without the tag, i.e. a string for scalars, a []any for sequences and a
+// map[string]any for mappings.
+type TagDecoder func(value any) (any, error)
+
+var (
+	tagDecoders     = map[string]TagDecoder{}
+	tagDecodersLock sync.RWMutex
+)
+
+// RegisterTagDecoder makes from-yaml use the decoder for all nodes with the
+// given tag. Decoders should be registered before any Rudi programs are
+// evaluated (e.g. in an init function).
+func RegisterTagDecoder(tag string, decoder TagDecoder) {
+	tagDecodersLock.Lock()
+	defer tagDecodersLock.Unlock()
+
+	tagDecoders[tag] = decoder
+}
+
+func getTagDecoder(tag string) (TagDecoder, bool) {
+	tagDecodersLock.RLock()
+	defer tagDecodersLock.RUnlock()
+
+	decoder, ok := tagDecoders[tag]
+
+	return decoder, ok
+}
+
 func toYamlFunction(val any) (any, error) {
 	encoded, err := yamlv3.Marshal(val)
 	if err != nil {
@@ -26,10 +59,123 @@ func toYamlFunction(val any) (any, error) {
 }
 
 func fromYamlFunction(encoded string) (any, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(encoded), &doc); err != nil {
+		return nil, err
+	}
+
+	// an empty document
+	if doc.Kind == 0 {
+		return nil, nil
+	}
+
+	d := &tagDecoding{values: map[string]any{}}
+	if err := d.replaceTaggedNodes(&doc); err != nil {
+		return nil, err
+	}
+
 	var result any
-	if err := yamlv3.Unmarshal([]byte(encoded), &result); err != nil {
+	if err := doc.Decode(&result); err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	return d.resolve(result), nil
+}
+
+// tagDecoding applies the registered tag decoders. Since yaml.v3 cannot
+// decode into custom types when decoding into an interface, each custom-tagged
+// node is decoded separately and then replaced with a unique placeholder
+// string. After yaml.v3 has decoded the entire document (taking care of
+// anchors, aliases and merge keys), the placeholders are replaced with the
+// decoded values.
+type tagDecoding struct {
+	// placeholder => decoded value
+	values map[string]any
+}
+
+func (d *tagDecoding) replaceTaggedNodes(node *yamlv3.Node) error {
+	// the node an alias points to is handled where it is defined
+	if node.Kind == yamlv3.AliasNode {
+		return nil
+	}
+
+	for i, child := range node.Content {
+		// custom tags on mapping keys are not supported
+		if node.Kind == yamlv3.MappingNode && i%2 == 0 {
+			continue
+		}
+
+		if err := d.replaceTaggedNodes(child); err != nil {
+			return err
+		}
+	}
+
+	decoder, ok := getTagDecoder(node.Tag)
+	if !ok {
+		return nil
+	}
+
+	var value any
+
+	if node.Kind == yamlv3.ScalarNode {
+		// do not let yaml.v3 interpret the custom-tagged scalar
+		value = node.Value
+	} else {
+		plain := *node
+		plain.Tag = ""
+
+		if err := plain.Decode(&value); err != nil {
+			return err
+		}
+
+		// the node's children might have been custom-tagged as well
+		value = d.resolve(value)
+	}
+
+	decoded, err := decoder(value)
+	if err != nil {
+		return fmt.Errorf("line %d: cannot decode %s: %w", node.Line, node.Tag, err)
+	}
+
+	placeholder := fmt.Sprintf("\x00rudi-yaml-tag-%p-%d", d, len(d.values))
+	d.values[placeholder] = decoded
+
+	*node = yamlv3.Node{
+		Kind:   yamlv3.ScalarNode,
+		Style:  yamlv3.DoubleQuotedStyle,
+		Tag:    "!!str",
+		Value:  placeholder,
+		Anchor: node.Anchor,
+		Line:   node.Line,
+		Column: node.Column,
+	}
+
+	return nil
+}
+
+// resolve replaces all placeholders in the decoded value.
+func (d *tagDecoding) resolve(value any) any {
+	switch v := value.(type) {
+	case string:
+		if decoded, ok := d.values[v]; ok {
+			return decoded
+		}
+
+	case []any:
+		for i, item := range v {
+			v[i] = d.resolve(item)
+		}
+
+	case map[string]any:
+		for key, item := range v {
+			v[key] = d.resolve(item)
+		}
+
+	case map[any]any:
+		for key, item := range v {
+			v[key] = d.resolve(item)
+		}
+	}
+
+	return value
 }
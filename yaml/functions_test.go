@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type upper string
+
+func TestFromYamlWithTagDecoders(t *testing.T) {
+	RegisterTagDecoder("!upper", func(value any) (any, error) {
+		str, ok := value.(string)
+		if !ok {
+			return nil, errors.New("not a string")
+		}
+
+		return upper(strings.ToUpper(str)), nil
+	})
+	defer delete(tagDecoders, "!upper")
+
+	RegisterTagDecoder("!join", func(value any) (any, error) {
+		items, ok := value.([]any)
+		if !ok {
+			return nil, errors.New("not a list")
+		}
+
+		strs := make([]string, len(items))
+		for i, item := range items {
+			strs[i] = fmt.Sprintf("%v", item)
+		}
+
+		return strings.Join(strs, ","), nil
+	})
+	defer delete(tagDecoders, "!join")
+
+	testcases := []struct {
+		input    string
+		expected any
+		invalid  bool
+	}{
+		{
+			input:    "",
+			expected: nil,
+		},
+		{
+			input:    "foo: 23",
+			expected: map[string]any{"foo": 23},
+		},
+		{
+			input:    "!upper foo",
+			expected: upper("FOO"),
+		},
+		{
+			input:    "!upper 42",
+			expected: upper("42"),
+		},
+		{
+			input:    "[1, !upper foo, {a: !upper b}]",
+			expected: []any{1, upper("FOO"), map[string]any{"a": upper("B")}},
+		},
+		{
+			input:    "base: &base {a: 1, b: 2}\nderived:\n  <<: *base\n  b: 3\n  c: !upper x",
+			expected: map[string]any{"base": map[string]any{"a": 1, "b": 2}, "derived": map[string]any{"a": 1, "b": 3, "c": upper("X")}},
+		},
+		{
+			input:   "!upper [foo]",
+			invalid: true,
+		},
+		{
+			input:    "a: &x !upper foo\nb: *x\nc: [*x]",
+			expected: map[string]any{"a": upper("FOO"), "b": upper("FOO"), "c": []any{upper("FOO")}},
+		},
+		{
+			input:    "base: &base {a: !upper x}\nderived:\n  <<: [*base, {b: 1}]\n  c: 2",
+			expected: map[string]any{"base": map[string]any{"a": upper("X")}, "derived": map[string]any{"a": upper("X"), "b": 1, "c": 2}},
+		},
+		{
+			input:    "1: !upper a\ntrue: b",
+			expected: map[any]any{1: upper("A"), true: "b"},
+		},
+		{
+			input:    "!join [a, !upper b]",
+			expected: "a,B",
+		},
+		{
+			input:    "a: \"!upper b\"",
+			expected: map[string]any{"a": "!upper b"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.input, func(t *testing.T) {
+			result, err := fromYamlFunction(testcase.input)
+			if err != nil {
+				if !testcase.invalid {
+					t.Fatalf("Failed to decode: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.invalid {
+				t.Fatalf("Expected error, but got %#v", result)
+			}
+
+			if !reflect.DeepEqual(testcase.expected, result) {
+				t.Fatalf("Expected %#v, but got %#v", testcase.expected, result)
+			}
+		})
+	}
+}
+
+func TestFromYamlWithoutTagDecoders(t *testing.T) {
+	// documents without registered tags must be decoded exactly like yaml.v3 does
+	inputs := []string{
+		"a: &x [1, 2]\nb: *x",
+		"base: &base {a: 1}\nderived: {<<: *base, b: 2}",
+		"1: a\n2.5: b\nnull: c",
+		"- !custom foo\n- bar",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			var expected any
+			if err := yamlv3.Unmarshal([]byte(input), &expected); err != nil {
+				t.Fatalf("Failed to decode using yaml.v3: %v", err)
+			}
+
+			result, err := fromYamlFunction(input)
+			if err != nil {
+				t.Fatalf("Failed to decode: %v", err)
+			}
+
+			if !reflect.DeepEqual(expected, result) {
+				t.Fatalf("Expected %#v, but got %#v", expected, result)
+			}
+		})
+	}
+}